@@ -15,6 +15,7 @@ package main
 import (
 	"flag"
 	"os"
+	"strings"
 
 	"github.com/maksim-paskal/k8s-resources-cli/internal"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
@@ -24,8 +25,24 @@ import (
 
 var version = flag.Bool("version", false, "show version")
 
+// exit code of lint command when rules with error level failed.
+const lintFailedExitCode = 2
+
 func main() {
-	flag.Parse()
+	command, args := "", os.Args[1:]
+
+	// command can be used before flags, for example k8s-resources-cli lint -lint.rules=rules.yaml
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		command, args = args[0], args[1:]
+	}
+
+	if err := flag.CommandLine.Parse(args); err != nil {
+		log.WithError(err).Fatal("error parse flags")
+	}
+
+	if len(command) == 0 {
+		command = flag.Arg(0)
+	}
 
 	logLevel, err := log.ParseLevel(*config.Get().LogLevel)
 	if err != nil {
@@ -53,7 +70,25 @@ func main() {
 		log.WithError(err).Fatal("error connecting kubernetes")
 	}
 
-	if err := internal.Run(); err != nil {
-		log.WithError(err).Fatal()
+	switch command {
+	case "":
+		if err := internal.Run(); err != nil {
+			log.WithError(err).Fatal()
+		}
+	case "tui":
+		if err := internal.TUI(); err != nil {
+			log.WithError(err).Fatal()
+		}
+	case "lint":
+		failed, err := internal.Lint()
+		if err != nil {
+			log.WithError(err).Fatal()
+		}
+
+		if failed {
+			os.Exit(lintFailedExitCode)
+		}
+	default:
+		log.Fatalf("unknown command %s", command)
 	}
 }
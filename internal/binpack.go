@@ -0,0 +1,74 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/binpack"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func writeBinpack(out io.Writer, pods []*types.PodResources) error {
+	nodeResources, err := api.GetNodes()
+	if err != nil {
+		return errors.Wrap(err, "error getting nodes")
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.Debug)
+
+	header := []string{
+		"NodePool",
+		"Nodes",
+		"CurrentRequestsNodes",
+		"RecomendedRequestsNodes",
+		"Difference",
+		"Unschedulable",
+	}
+
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	total := binpack.Result{NodePool: "total"}
+
+	results := binpack.Simulate(nodeResources, pods)
+
+	for _, result := range results {
+		total.Nodes += result.Nodes
+		total.CurrentNodes += result.CurrentNodes
+		total.RecomendedNodes += result.RecomendedNodes
+		total.Unschedulable += result.Unschedulable
+	}
+
+	for _, result := range append(results, &total) {
+		item := []string{
+			result.NodePool,
+			strconv.Itoa(result.Nodes),
+			strconv.Itoa(result.CurrentNodes),
+			strconv.Itoa(result.RecomendedNodes),
+			fmt.Sprintf("%+d", result.RecomendedNodes-result.Nodes),
+			strconv.Itoa(result.Unschedulable),
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
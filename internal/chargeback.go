@@ -0,0 +1,76 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/chargeback"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func writeChargeback(out io.Writer, pods []*types.PodResources) {
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.Debug)
+
+	header := []string{
+		*config.Get().ChargebackLabel,
+		"Containers",
+		"CPURequest",
+		"CPUUsage",
+		"CPUEfficiency",
+		"MemoryRequest",
+		"MemoryUsage",
+		"MemoryEfficiency",
+	}
+
+	if config.Get().Cost != nil {
+		header = append(header, "MonthlyCost")
+	}
+
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, group := range chargeback.Get(pods, *config.Get().ChargebackLabel) {
+		item := []string{
+			group.Name,
+			strconv.Itoa(group.Containers),
+			types.FormatResource(types.CPUResourcePlaningType, group.CPURequest),
+			types.FormatResource(types.CPUResourcePlaningType, group.CPUUsage),
+			formatPercents(group.CPUEfficiency()),
+			types.FormatResource(types.MemoryResourcePlaningType, group.MemoryRequest),
+			types.FormatResource(types.MemoryResourcePlaningType, group.MemoryUsage),
+			formatPercents(group.MemoryEfficiency()),
+		}
+
+		if config.Get().Cost != nil {
+			item = append(item, fmt.Sprintf("%.2f", group.MonthlyCost))
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	w.Flush()
+}
+
+// formatPercents formats percents, negative values are unknown.
+func formatPercents(value float64) string {
+	if value < 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.0f%%", value)
+}
@@ -0,0 +1,64 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// writeExplain writes how recomendations of every container were calculated.
+func writeExplain(b *bytes.Buffer, pods []*types.PodResources) {
+	for _, pod := range pods {
+		recomendation := pod.GetRecomendation()
+		if recomendation == nil || len(recomendation.Explain) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(b)
+		fmt.Fprintf(b, "%s/%s:\n", pod.GetPodNamespaceName(), pod.ContainerName)
+
+		writePodExplain(b, pod)
+	}
+}
+
+// writePodExplain writes how recomendations of container were calculated.
+func writePodExplain(out io.Writer, pod *types.PodResources) {
+	if recomendation := pod.GetRecomendation(); recomendation != nil {
+		for _, explanation := range recomendation.Explain {
+			fmt.Fprintf(out, "  %s:\n", explanation.Resource)
+
+			if len(explanation.Strategy) > 0 {
+				fmt.Fprintf(out, "    strategy: %s\n", explanation.Strategy)
+			}
+
+			fmt.Fprintf(out, "    function: %s\n", explanation.Function)
+			fmt.Fprintf(out, "    query: %s\n", explanation.Query)
+			fmt.Fprintf(out, "    value: %s\n", explanation.Value)
+
+			if len(explanation.Adjustments) > 0 {
+				fmt.Fprintf(out, "    adjustments: %s\n", strings.Join(explanation.Adjustments, ", "))
+			}
+		}
+	}
+
+	for _, planingType := range []types.ResourcePlaningType{types.MemoryResourcePlaningType, types.CPUResourcePlaningType} { //nolint:lll
+		score, reason := pod.ExplainScore(planingType)
+
+		fmt.Fprintf(out, "  %s request score: %s, %s\n", planingType, score.Name(), reason)
+	}
+}
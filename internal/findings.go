@@ -0,0 +1,67 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/findings"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/lint"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// writeFindings writes results of rules in SARIF or JUnit format.
+func writeFindings(b *bytes.Buffer, outputFormat types.OutputFormat, rules []*findings.Rule, results []findings.Result) error { //nolint:lll
+	switch outputFormat { //nolint:exhaustive
+	case types.OutputFormatSARIF:
+		return findings.WriteSARIF(b, rules, results, config.GetVersion()) //nolint:wrapcheck
+	case types.OutputFormatJUnit:
+		return findings.WriteJUnit(b, rules, results) //nolint:wrapcheck
+	default:
+		return errors.Errorf("output %s is not supported for findings", outputFormat)
+	}
+}
+
+// lintFindings returns lint rules and their results on every container.
+func lintFindings(rules []*lint.Rule, pods []*types.PodResources) ([]*findings.Rule, []findings.Result, error) {
+	findingRules := make([]*findings.Rule, 0, len(rules))
+	results := make([]findings.Result, 0)
+
+	for _, rule := range rules {
+		findingRule := &findings.Rule{ID: rule.Name, Description: rule.Description, Level: findings.Level(rule.Level)}
+
+		if len(findingRule.Description) == 0 {
+			findingRule.Description = rule.Expr
+		}
+
+		findingRules = append(findingRules, findingRule)
+
+		for _, pod := range pods {
+			ok, err := rule.Check(pod)
+			if err != nil {
+				return nil, nil, errors.Wrapf(err, "error checking rule %s on %s", rule.Name, pod.GetPodNamespaceName())
+			}
+
+			results = append(results, findings.Result{
+				Rule:    findingRule,
+				Pod:     pod,
+				Failed:  !ok,
+				Message: findingRule.Description,
+			})
+		}
+	}
+
+	return findingRules, results, nil
+}
@@ -0,0 +1,53 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// writeOOMHistory writes OOMKilled events of every container that was killed.
+func writeOOMHistory(b *bytes.Buffer, pods []*types.PodResources) {
+	for _, pod := range pods {
+		if len(pod.OOMHistory) == 0 {
+			continue
+		}
+
+		fmt.Fprintln(b)
+		fmt.Fprintf(b, "%s/%s OOMKilled history:\n", pod.GetPodNamespaceName(), pod.ContainerName)
+
+		w := tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.Debug)
+
+		fmt.Fprintln(w, strings.Join([]string{"Time", "MemoryLimit", "Source", "Count"}, "\t"))
+
+		for _, event := range pod.OOMHistory {
+			item := []string{
+				event.Time.UTC().Format(time.RFC3339),
+				event.MemoryLimit,
+				string(event.Source),
+				strconv.Itoa(event.GetCount()),
+			}
+
+			fmt.Fprintln(w, strings.Join(item, "\t"))
+		}
+
+		w.Flush()
+	}
+}
@@ -0,0 +1,145 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	_ "embed"
+	"html/template"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+//go:embed report.html
+var reportHTML string
+
+//nolint:gochecknoglobals
+var reportTemplate = template.Must(template.New("report").Parse(reportHTML))
+
+type htmlCell struct {
+	Value string
+	Class string
+	Sort  string // numeric value of quantity to sort, empty for text
+}
+
+type htmlTable struct {
+	Name   string
+	Header []string
+	Rows   [][]htmlCell
+	Footer [][]htmlCell // rows that are not sorted
+}
+
+type htmlReport struct {
+	Title     string
+	Generated string
+	Sections  []htmlTable
+	Totals    htmlTable
+}
+
+// scoreColumns are columns that are colored by planing score of request.
+//
+//nolint:gochecknoglobals
+var scoreColumns = map[string]string{
+	"MemoryRequest": "MemoryRequestScore",
+	"CPURequest":    "CPURequestScore",
+}
+
+// writeHTML writes pods report as single html file with sortable tables of namespaces and totals.
+func writeHTML(b *bytes.Buffer, pods []*types.PodResources) error {
+	podsTable, _, err := getPodsTable(pods)
+	if err != nil {
+		return err
+	}
+
+	report := htmlReport{
+		Title:     "Resources report",
+		Generated: time.Now().Format(time.RFC3339),
+		// totals are calculated for all results, not only for top results
+		Totals: getSummaryTable(pods).html("Totals"),
+	}
+
+	// cluster totals are always last
+	if rows := report.Totals.Rows; len(rows) > 0 {
+		report.Totals.Rows, report.Totals.Footer = rows[:len(rows)-1], rows[len(rows)-1:]
+	}
+
+	namespaces, tables := podsTable.byNamespace()
+
+	for _, namespace := range namespaces {
+		report.Sections = append(report.Sections, tables[namespace].html(namespace))
+	}
+
+	if err := reportTemplate.Execute(b, report); err != nil {
+		return errors.Wrap(err, "error executing html template")
+	}
+
+	return nil
+}
+
+func (t *table) html(name string) htmlTable {
+	result := htmlTable{Name: name, Header: t.header}
+
+	for _, row := range t.rows {
+		cells := make([]htmlCell, 0, len(row.cells))
+
+		for i, cell := range row.cells {
+			cells = append(cells, htmlCell{
+				Value: cell,
+				Class: scoreClass(row.pod, t.header[i]),
+				Sort:  sortValue(cell),
+			})
+		}
+
+		result.Rows = append(result.Rows, cells)
+	}
+
+	return result
+}
+
+// scoreClass returns css class of planing score of request, empty if score is unknown.
+func scoreClass(pod *types.PodResources, column string) string {
+	field, ok := scoreColumns[column]
+	if pod == nil || !ok || pod.GetRecomendation() == nil {
+		return ""
+	}
+
+	score, err := pod.GetFieldValue(field)
+	if err != nil || score == types.UnknownResourcePlaningResult.Name() {
+		return ""
+	}
+
+	return "score-" + strings.ToLower(score)
+}
+
+// sortValue returns value of quantity in the beginning of cell (for example "1Gi / 512Mi OK" or "33%"),
+// empty if cell is not a quantity.
+func sortValue(cell string) string {
+	fields := strings.Fields(cell)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	value := strings.TrimSuffix(fields[0], "%")
+
+	if _, err := resource.ParseQuantity(value); err != nil {
+		return ""
+	}
+
+	return strconv.FormatFloat(utils.QuantityToFloat(value), 'f', -1, 64)
+}
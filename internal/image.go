@@ -0,0 +1,74 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// writeImageChanges writes regressions and improvements of usage between consecutive image versions.
+func writeImageChanges(b *bytes.Buffer, pods []*types.PodResources) {
+	containers := make(map[string]bool)
+
+	var w *tabwriter.Writer
+
+	for _, pod := range pods {
+		container := fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.PodTemplate, pod.ContainerName)
+		if len(pod.PodTemplate) == 0 {
+			container = fmt.Sprintf("%s/%s/%s", pod.Namespace, pod.PodName, pod.ContainerName)
+		}
+
+		if containers[container] {
+			continue
+		}
+
+		containers[container] = true
+
+		for _, change := range types.GetImageChanges(pod.ImageVersions) {
+			verdict := change.Verdict(*config.Get().ImageRegression)
+			if len(verdict) == 0 {
+				continue
+			}
+
+			if w == nil {
+				fmt.Fprintln(b)
+				fmt.Fprintln(b, "Image changes:")
+
+				w = tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.Debug)
+
+				fmt.Fprintln(w, strings.Join([]string{"Container", "From", "To", "MemoryChange", "CPUChange", "Verdict"}, "\t"))
+			}
+
+			item := []string{
+				container,
+				change.From,
+				change.To,
+				fmt.Sprintf("%+.0f%%", change.MemoryChange),
+				fmt.Sprintf("%+.0f%%", change.CPUChange),
+				verdict,
+			}
+
+			fmt.Fprintln(w, strings.Join(item, "\t"))
+		}
+	}
+
+	if w != nil {
+		w.Flush()
+	}
+}
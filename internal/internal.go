@@ -18,24 +18,133 @@ import (
 	"os"
 	"sort"
 	"strconv"
-	"strings"
-	"text/tabwriter"
 
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/findings"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
 	"github.com/pkg/errors"
 	log "github.com/sirupsen/logrus"
 )
 
-func Run() error { //nolint:funlen,cyclop
+func Run() error {
 	pods, err := api.GetPodResources()
 	if err != nil {
 		return err //nolint:wrapcheck
 	}
 
+	if len(pods) == 0 {
+		return errors.New("no pods found")
+	}
+
+	reportType, err := types.ParseReportType(*config.Get().Report)
+	if err != nil {
+		return errors.Wrap(err, "error parsing report type")
+	}
+
+	outputFormat, err := types.ParseOutputFormat(*config.Get().Output)
+	if err != nil {
+		return errors.Wrap(err, "error parsing output format")
+	}
+
 	var b bytes.Buffer
-	w := tabwriter.NewWriter(&b, 0, 0, 1, ' ', tabwriter.Debug)
 
+	switch reportType {
+	case types.ReportTypeChargeback:
+		writeChargeback(&b, pods)
+	case types.ReportTypeNodes:
+		if err := writeNodes(&b, pods); err != nil {
+			return err
+		}
+	case types.ReportTypeBinpack:
+		if err := writeBinpack(&b, pods); err != nil {
+			return err
+		}
+	case types.ReportTypeReplicas:
+		if err := writeReplicas(&b, pods); err != nil {
+			return err
+		}
+	case types.ReportTypePolicies:
+		if err := writePolicies(&b, pods); err != nil {
+			return err
+		}
+	case types.ReportTypePods:
+		if err := writePodsOutput(&b, outputFormat, pods); err != nil {
+			return err
+		}
+	}
+
+	fmt.Println(b.String()) //nolint:forbidigo
+
+	const filePermission = 0o755
+
+	err = os.WriteFile("result.txt", b.Bytes(), os.FileMode(filePermission))
+	if err != nil {
+		log.WithError(err).Error("error writing result to file")
+	}
+
+	return nil
+}
+
+// writePodsOutput writes pods report in output format.
+func writePodsOutput(b *bytes.Buffer, outputFormat types.OutputFormat, pods []*types.PodResources) error {
+	switch outputFormat {
+	case types.OutputFormatMarkdown:
+		return writeMarkdown(b, pods)
+	case types.OutputFormatHTML:
+		return writeHTML(b, pods)
+	case types.OutputFormatTemplate:
+		return writeTemplate(b, pods)
+	case types.OutputFormatSARIF, types.OutputFormatJUnit:
+		// findings are written instead of table
+		return writeFindings(b, outputFormat, findings.GetRules(), findings.Check(pods))
+	case types.OutputFormatText:
+		return writePods(b, pods)
+	default:
+		return errors.Errorf("unknown output format %s", outputFormat)
+	}
+}
+
+func writePods(b *bytes.Buffer, pods []*types.PodResources) error {
+	podsTable, rows, err := getPodsTable(pods)
+	if err != nil {
+		return err
+	}
+
+	podsTable.writeText(b)
+
+	if *config.Get().ShowRestarts {
+		writeOOMHistory(b, rows)
+	}
+
+	if *config.Get().Seasonality {
+		writeScheduledScaling(b, rows)
+	}
+
+	if *config.Get().ImageSplit {
+		writeImageChanges(b, rows)
+	}
+
+	if *config.Get().Explain {
+		writeExplain(b, rows)
+	}
+
+	// quota is shared by all pods of namespace, not only by top results
+	if err := writeQuotaHeadroom(b, pods); err != nil {
+		return err
+	}
+
+	if *config.Get().ShowSummary {
+		fmt.Fprintln(b)
+		// summary is calculated for all results, not only for top results
+		writeSummary(b, pods)
+	}
+
+	return nil
+}
+
+// getPodsTable returns table of pods report and pods of table rows after sorting and -top.
+func getPodsTable(pods []*types.PodResources) (*table, []*types.PodResources, error) { //nolint:funlen,cyclop
 	header := []string{
 		"PodName",
 		"ContainerName",
@@ -45,6 +154,23 @@ func Run() error { //nolint:funlen,cyclop
 		"CPULimit",
 	}
 
+	strategies, err := types.ParseStrategies(*config.Get().Strategy, config.Get().CustomStrategies)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing strategy")
+	}
+
+	// limits of additional strategies are calculated only with metrics
+	if len(*config.Get().PrometheusURL) == 0 {
+		strategies = strategies[:1]
+	}
+
+	for i := 1; i < len(strategies); i++ {
+		header = append(header,
+			types.LimitResourceName("MemoryLimit", i, strategies[i].Name),
+			types.LimitResourceName("CPULimit", i, strategies[i].Name),
+		)
+	}
+
 	if *config.Get().ShowQoS {
 		header = append(header, "QoS")
 	}
@@ -53,22 +179,64 @@ func Run() error { //nolint:funlen,cyclop
 		header = append(header, "SafeToEvict")
 	}
 
-	if *config.Get().ShowDebugJSON {
-		header = append(header, "Debug")
+	if *config.Get().ShowRestarts {
+		header = append(header, "Restarts", "OOMCount", "LastOOM")
 	}
 
-	fmt.Fprintln(w, strings.Join(header, "\t"))
+	showHPA := hasHPA(pods)
 
-	if len(pods) == 0 {
-		return errors.New("no pods found")
+	if showHPA {
+		header = append(header, "HPA")
+	}
+
+	showLimitRange := hasLimitRange(pods)
+
+	if showLimitRange {
+		header = append(header, "LimitRange")
+	}
+
+	showStartup := len(*config.Get().StartupIgnore) > 0
+
+	if showStartup {
+		header = append(header, "StartupMemoryLimit", "StartupCPULimit")
 	}
 
+	if *config.Get().Seasonality {
+		header = append(header, "PeakWindow", "PeakRatio")
+	}
+
+	// confidence of recomendations is known only with metrics
+	showConfidence := len(*config.Get().PrometheusURL) > 0
+
+	if showConfidence {
+		header = append(header, "Confidence")
+	}
+
+	if *config.Get().ShowDebugJSON {
+		header = append(header, "Debug")
+	}
+
+	podsTable := &table{header: header}
+
 	// sort pods by namespace and name
 	sort.Slice(pods, func(i, j int) bool {
 		return pods[i].GetPodNamespaceName() < pods[j].GetPodNamespaceName()
 	})
 
-	for _, result := range pods {
+	sortBy, err := types.ParseSortBy(*config.Get().SortBy)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing sort-by")
+	}
+
+	types.SortPodResources(pods, sortBy)
+
+	rows := pods
+
+	if top := *config.Get().Top; top > 0 && top < len(rows) {
+		rows = rows[:top]
+	}
+
+	for _, result := range rows {
 		item := make([]string, 0)
 
 		formattedResources := result.GetFormattedResources()
@@ -93,10 +261,18 @@ func Run() error { //nolint:funlen,cyclop
 			memoryLimit += " OOMKilled"
 		}
 
+		if result.OOMRisk == types.OOMRiskHigh {
+			memoryLimit += fmt.Sprintf(" OOMRisk %sh", result.HoursToMemoryLimit)
+		}
+
 		item = append(item, memoryLimit)
 		item = append(item, formattedResources.CPURequest)
 		item = append(item, formattedResources.CPULimit)
 
+		for i := 1; i < len(strategies); i++ {
+			item = append(item, formatStrategyLimits(result, i-1)...)
+		}
+
 		if *config.Get().ShowQoS {
 			item = append(item, result.QoS)
 		}
@@ -105,23 +281,109 @@ func Run() error { //nolint:funlen,cyclop
 			item = append(item, strconv.FormatBool(result.SafeToEvict))
 		}
 
+		if *config.Get().ShowRestarts {
+			lastOOM, _ := result.GetFieldValue("LastOOM")
+
+			item = append(item, strconv.Itoa(result.Restarts), strconv.Itoa(result.GetOOMCount()), lastOOM)
+		}
+
+		if showHPA {
+			item = append(item, formatHPA(result))
+		}
+
+		if showLimitRange {
+			item = append(item, formatLimitRange(result))
+		}
+
+		if showStartup {
+			startupMemoryLimit, _ := result.GetFieldValue("StartupMemoryLimit")
+			startupCPULimit, _ := result.GetFieldValue("StartupCPULimit")
+
+			item = append(item, startupMemoryLimit, startupCPULimit)
+		}
+
+		if *config.Get().Seasonality {
+			peakRatio, _ := result.GetFieldValue("PeakRatio")
+
+			item = append(item, result.Seasonality.GetPeakWindow(), peakRatio)
+		}
+
+		if showConfidence {
+			item = append(item, formatConfidence(result))
+		}
+
 		if *config.Get().ShowDebugJSON {
 			item = append(item, result.String())
 		}
 
-		fmt.Fprintln(w, strings.Join(item, "\t"))
+		podsTable.rows = append(podsTable.rows, tableRow{pod: result, cells: item})
 	}
 
-	w.Flush()
+	columns, err := types.ParseColumns(*config.Get().Columns)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "error parsing columns")
+	}
 
-	fmt.Println(b.String()) //nolint:forbidigo
+	if len(columns) > 0 {
+		podsTable = podsTable.selectColumns(columns)
+	}
 
-	const filePermission = 0o755
+	return podsTable, rows, nil
+}
 
-	err = os.WriteFile("result.txt", b.Bytes(), os.FileMode(filePermission))
-	if err != nil {
-		log.WithError(err).Error("error writing result to file")
+// formatConfidence returns confidence of recomendation with hours of metrics.
+func formatConfidence(pod *types.PodResources) string {
+	recomendation := pod.GetRecomendation()
+	if recomendation == nil || len(recomendation.Confidence) == 0 {
+		return ""
 	}
 
-	return nil
+	return fmt.Sprintf("%s %.0fh", recomendation.Confidence, recomendation.DataSpan.Hours())
+}
+
+// formatStrategyLimits returns current and recomended limits of additional strategy.
+func formatStrategyLimits(pod *types.PodResources, index int) []string {
+	memoryLimit := pod.MemoryLimit
+	cpuLimit := pod.CPULimit
+
+	if recomendation := pod.GetRecomendation(); recomendation != nil && index < len(recomendation.Strategies) {
+		limits := recomendation.Strategies[index]
+
+		if len(limits.MemoryLimit) > 0 {
+			memoryLimit = fmt.Sprintf("%s / %s", pod.MemoryLimit, limits.MemoryLimit)
+		}
+
+		if len(limits.CPULimit) > 0 {
+			cpuLimit = fmt.Sprintf("%s / %s", pod.CPULimit, limits.CPULimit)
+		}
+	}
+
+	return []string{memoryLimit, cpuLimit}
+}
+
+func hasHPA(pods []*types.PodResources) bool {
+	for _, pod := range pods {
+		if pod.HPA != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatHPA returns autoscaler with effect of recomended requests.
+func formatHPA(pod *types.PodResources) string {
+	if pod.HPA == nil {
+		return ""
+	}
+
+	result := pod.HPA.String()
+
+	if recomendation := pod.GetRecomendation(); recomendation != nil {
+		if effect := recomendation.HPA.String(); len(effect) > 0 {
+			result += " " + effect
+		}
+	}
+
+	return result
 }
@@ -0,0 +1,120 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/lint"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Lint checks containers with rules file, returns true if rules with error level failed.
+func Lint() (bool, error) {
+	rules, err := lint.Load(*config.Get().LintRules)
+	if err != nil {
+		return false, errors.Wrap(err, "error loading lint rules")
+	}
+
+	if err := checkLintRules(rules); err != nil {
+		return false, err
+	}
+
+	pods, err := api.GetPodResources()
+	if err != nil {
+		return false, err //nolint:wrapcheck
+	}
+
+	violations, err := lint.Evaluate(rules, pods)
+	if err != nil {
+		return false, errors.Wrap(err, "error evaluating lint rules")
+	}
+
+	outputFormat, err := types.ParseOutputFormat(*config.Get().Output)
+	if err != nil {
+		return false, errors.Wrap(err, "error parsing output format")
+	}
+
+	var b bytes.Buffer
+
+	if outputFormat == types.OutputFormatText {
+		writeLint(&b, violations)
+	} else {
+		findingRules, results, err := lintFindings(rules, pods)
+		if err != nil {
+			return false, err
+		}
+
+		if err := writeFindings(&b, outputFormat, findingRules, results); err != nil {
+			return false, err
+		}
+	}
+
+	fmt.Print(b.String()) //nolint:forbidigo
+
+	return lint.HasErrors(violations), nil
+}
+
+// checkLintRules returns error if rules use recomendation fields without -prometheus.url.
+func checkLintRules(rules []*lint.Rule) error {
+	if len(*config.Get().PrometheusURL) > 0 {
+		return nil
+	}
+
+	for _, rule := range rules {
+		for _, name := range rule.Fields() {
+			if field, _ := types.GetField(name); field != nil && field.Recomendation {
+				return errors.Errorf("rule %s uses field %s that requires -prometheus.url", rule.Name, name)
+			}
+		}
+	}
+
+	return nil
+}
+
+func writeLint(b *bytes.Buffer, violations []lint.Violation) {
+	errorsCount := 0
+
+	if len(violations) > 0 {
+		w := tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.Debug)
+
+		fmt.Fprintln(w, strings.Join([]string{"Level", "Rule", "PodName", "ContainerName", "Description"}, "\t"))
+
+		for _, violation := range violations {
+			if violation.Rule.Level == lint.LevelError {
+				errorsCount++
+			}
+
+			item := []string{
+				string(violation.Rule.Level),
+				violation.Rule.Name,
+				violation.Pod.GetPodNamespaceName(),
+				violation.Pod.ContainerName,
+				violation.Rule.Description,
+			}
+
+			fmt.Fprintln(w, strings.Join(item, "\t"))
+		}
+
+		w.Flush()
+		fmt.Fprintln(b)
+	}
+
+	fmt.Fprintf(b, "%d errors, %d warnings\n", errorsCount, len(violations)-errorsCount)
+}
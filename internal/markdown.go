@@ -0,0 +1,44 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// writeMarkdown writes pods report as markdown tables of namespaces with totals.
+func writeMarkdown(b *bytes.Buffer, pods []*types.PodResources) error {
+	podsTable, _, err := getPodsTable(pods)
+	if err != nil {
+		return err
+	}
+
+	fmt.Fprintln(b, "# Resources report")
+
+	namespaces, tables := podsTable.byNamespace()
+
+	for _, namespace := range namespaces {
+		fmt.Fprintf(b, "\n## %s\n\n", namespace)
+		tables[namespace].writeMarkdown(b)
+	}
+
+	fmt.Fprint(b, "\n## Totals\n\n")
+
+	// totals are calculated for all results, not only for top results
+	getSummaryTable(pods).writeMarkdown(b)
+
+	return nil
+}
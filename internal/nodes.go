@@ -0,0 +1,109 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/nodes"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+const percents = 100
+
+func writeNodes(out io.Writer, pods []*types.PodResources) error {
+	nodeResources, err := api.GetNodes()
+	if err != nil {
+		return errors.Wrap(err, "error getting nodes")
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.Debug)
+
+	header := []string{
+		"NodeName",
+		"NodePool",
+		"Pods",
+		"CPUAllocatable",
+		"CPURequest",
+		"CPULimit",
+		"CPUUsage",
+		"RecomendedCPURequest",
+		"MemoryAllocatable",
+		"MemoryRequest",
+		"MemoryLimit",
+		"MemoryUsage",
+		"RecomendedMemoryRequest",
+		"Overcommitted",
+	}
+
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, node := range nodes.Get(nodeResources, pods) {
+		cpu := func(value float64) string {
+			return formatAllocation(types.CPUResourcePlaningType, value, node.AllocatableCPU)
+		}
+
+		memory := func(value float64) string {
+			return formatAllocation(types.MemoryResourcePlaningType, value, node.AllocatableMemory)
+		}
+
+		overcommitted := make([]string, 0)
+
+		if node.CPUOvercommit() > *config.Get().NodesOvercommit {
+			overcommitted = append(overcommitted, "cpu")
+		}
+
+		if node.MemoryOvercommit() > *config.Get().NodesOvercommit {
+			overcommitted = append(overcommitted, "memory")
+		}
+
+		item := []string{
+			node.NodeName,
+			node.NodePool,
+			strconv.Itoa(node.Pods),
+			types.FormatResource(types.CPUResourcePlaningType, node.AllocatableCPU),
+			cpu(node.CPURequest),
+			cpu(node.CPULimit),
+			cpu(node.CPUUsage),
+			cpu(node.RecomendedCPURequest),
+			types.FormatResource(types.MemoryResourcePlaningType, node.AllocatableMemory),
+			memory(node.MemoryRequest),
+			memory(node.MemoryLimit),
+			memory(node.MemoryUsage),
+			memory(node.RecomendedMemoryRequest),
+			strings.Join(overcommitted, ","),
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+// formatAllocation formats resource with percents of allocatable resource.
+func formatAllocation(planingType types.ResourcePlaningType, value, allocatable float64) string {
+	if allocatable == 0 {
+		return types.FormatResource(planingType, value)
+	}
+
+	return fmt.Sprintf("%s (%.0f%%)", types.FormatResource(planingType, value), value/allocatable*percents)
+}
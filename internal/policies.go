@@ -0,0 +1,59 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/policy"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	"sigs.k8s.io/yaml"
+)
+
+// writePolicies writes proposed LimitRange and ResourceQuota of every namespace as yaml documents.
+func writePolicies(b *bytes.Buffer, pods []*types.PodResources) error {
+	proposals := policy.Propose(pods, *config.Get().PoliciesHeadroom, recomender.IsConfident)
+
+	for i, proposal := range proposals {
+		if i > 0 {
+			fmt.Fprintln(b, "---")
+		}
+
+		fmt.Fprintf(b, "# namespace %s, %d containers", proposal.Namespace, proposal.Containers)
+
+		if proposal.NotConfident > 0 {
+			fmt.Fprintf(b, ", current resources of %d containers without confident recomendation", proposal.NotConfident)
+		}
+
+		fmt.Fprintln(b)
+
+		for j, object := range []interface{}{proposal.LimitRange, proposal.ResourceQuota} {
+			if j > 0 {
+				fmt.Fprintln(b, "---")
+			}
+
+			out, err := yaml.Marshal(object)
+			if err != nil {
+				return errors.Wrap(err, "error marshal yaml")
+			}
+
+			b.Write(out)
+		}
+	}
+
+	return nil
+}
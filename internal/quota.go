@@ -0,0 +1,114 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func hasLimitRange(pods []*types.PodResources) bool {
+	for _, pod := range pods {
+		if pod.LimitRange != nil || len(pod.LimitRangeDefaults) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// formatLimitRange returns resources set by LimitRange defaults and recomendations out of LimitRange.
+func formatLimitRange(pod *types.PodResources) string {
+	result := make([]string, 0)
+
+	if len(pod.LimitRangeDefaults) > 0 {
+		result = append(result, "defaults "+strings.Join(pod.LimitRangeDefaults, ","))
+	}
+
+	if recomendation := pod.GetRecomendation(); recomendation != nil {
+		for _, violation := range recomendation.LimitRangeViolations {
+			if *config.Get().LimitRangeClamp {
+				violation += " clamped"
+			}
+
+			result = append(result, violation)
+		}
+	}
+
+	return strings.Join(result, "; ")
+}
+
+// writeQuotaHeadroom writes headroom of ResourceQuotas of namespaces before and after applying recomendations.
+func writeQuotaHeadroom(b *bytes.Buffer, pods []*types.PodResources) error {
+	quotas, err := api.GetResourceQuotas(pods)
+	if err != nil {
+		return errors.Wrap(err, "error getting resource quotas")
+	}
+
+	headrooms := types.GetQuotaHeadroom(quotas, pods)
+	if len(headrooms) == 0 {
+		return nil
+	}
+
+	// recomendations are calculated only with metrics
+	showAfter := len(*config.Get().PrometheusURL) > 0
+
+	fmt.Fprintln(b)
+	fmt.Fprintln(b, "Quota headroom:")
+
+	w := tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.Debug)
+
+	header := []string{"Namespace", "ResourceQuota", "Resource", "Hard", "Used", "Headroom"}
+
+	if showAfter {
+		header = append(header, "HeadroomAfter")
+	}
+
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, headroom := range headrooms {
+		planingType := headroom.PlaningType()
+
+		item := []string{
+			headroom.Namespace,
+			headroom.Name,
+			headroom.Resource,
+			types.FormatResource(planingType, headroom.Hard),
+			types.FormatResource(planingType, headroom.Used),
+			types.FormatResource(planingType, headroom.Headroom),
+		}
+
+		if showAfter {
+			after := types.FormatResource(planingType, headroom.HeadroomAfter)
+
+			if headroom.Exceeded() {
+				after += " exceeded"
+			}
+
+			item = append(item, after)
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
@@ -0,0 +1,102 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/replicas"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+func writeReplicas(out io.Writer, pods []*types.PodResources) error {
+	// memory usage of replicas does not scale with load
+	fmt.Fprintln(out, "Proposals are based on cpu usage only, memory usage is not analyzed")
+	fmt.Fprintln(out)
+
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.Debug)
+
+	header := []string{
+		"Workload",
+		"ContainerName",
+		"Replicas",
+		"CPURequest",
+		"PeakCPU",
+		"MedianCPU",
+		"Utilization",
+		"ProposedReplicas",
+		"ProposedCPURequest",
+		"HPAMinReplicas",
+	}
+
+	fmt.Fprintln(w, strings.Join(header, "\t"))
+
+	for _, workload := range replicas.GetWorkloads(pods) {
+		if err := recomender.SetWorkloadUsage(workload); err != nil {
+			return errors.Wrap(err, "error getting workload usage")
+		}
+
+		proposal := replicas.Propose(workload, *config.Get().ReplicasHeadroom, *config.Get().ReplicasMin)
+		if proposal == nil {
+			continue
+		}
+
+		// replicas of autoscaler are changed by autoscaler
+		minReplicas := formatReplicas(proposal.MinReplicas)
+		if workload.HPA != nil {
+			minReplicas = fmt.Sprintf("%d / %s", workload.HPA.MinReplicas, minReplicas)
+		}
+
+		item := []string{
+			fmt.Sprintf("%s/%s", workload.Namespace, workload.Name),
+			workload.ContainerName,
+			strconv.Itoa(workload.Replicas),
+			types.FormatResource(types.CPUResourcePlaningType, workload.CPURequest),
+			types.FormatResource(types.CPUResourcePlaningType, proposal.PeakUsage),
+			types.FormatResource(types.CPUResourcePlaningType, proposal.MedianUsage),
+			formatUtilization(proposal),
+			fmt.Sprintf("%s x %s", formatReplicas(proposal.Replicas), types.FormatResource(types.CPUResourcePlaningType, workload.CPURequest)), //nolint:lll
+			fmt.Sprintf("%d x %s", workload.Replicas, types.FormatResource(types.CPUResourcePlaningType, proposal.CPURequest)),
+			minReplicas,
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	w.Flush()
+
+	return nil
+}
+
+func formatReplicas(value int) string {
+	if value == 0 {
+		return "-"
+	}
+
+	return strconv.Itoa(value)
+}
+
+func formatUtilization(proposal *replicas.Proposal) string {
+	if proposal.Workload.CPURequest == 0 {
+		return "-"
+	}
+
+	return fmt.Sprintf("%.0f%%", proposal.Utilization)
+}
@@ -0,0 +1,71 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// writeScheduledScaling writes workloads with big difference between peak and off-peak usage.
+func writeScheduledScaling(b *bytes.Buffer, pods []*types.PodResources) {
+	workloads := make(map[string]bool)
+
+	var w *tabwriter.Writer
+
+	for _, pod := range pods {
+		if pod.Seasonality == nil || !pod.Seasonality.ScheduledScaling {
+			continue
+		}
+
+		workload := fmt.Sprintf("%s/%s", pod.Namespace, pod.PodName)
+		if len(pod.PodTemplate) > 0 {
+			workload = fmt.Sprintf("%s/%s", pod.Namespace, pod.PodTemplate)
+		}
+
+		workload += "/" + pod.ContainerName
+
+		if workloads[workload] {
+			continue
+		}
+
+		workloads[workload] = true
+
+		if w == nil {
+			fmt.Fprintln(b)
+			fmt.Fprintln(b, "Scheduled scaling candidates:")
+
+			w = tabwriter.NewWriter(b, 0, 0, 1, ' ', tabwriter.Debug)
+
+			fmt.Fprintln(w, strings.Join([]string{"Workload", "PeakWindow", "PeakRatio", "PeakCPURequest", "PeakMemoryRequest"}, "\t")) //nolint:lll
+		}
+
+		item := []string{
+			workload,
+			pod.Seasonality.GetPeakWindow(),
+			pod.Seasonality.PeakRatio,
+			pod.Seasonality.PeakCPURequest,
+			pod.Seasonality.PeakMemoryRequest,
+		}
+
+		fmt.Fprintln(w, strings.Join(item, "\t"))
+	}
+
+	if w != nil {
+		w.Flush()
+	}
+}
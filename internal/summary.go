@@ -0,0 +1,70 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/summary"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func writeSummary(out io.Writer, pods []*types.PodResources) {
+	getSummaryTable(pods).writeText(out)
+}
+
+// getSummaryTable returns totals of namespaces and cluster.
+func getSummaryTable(pods []*types.PodResources) *table {
+	header := []string{
+		"Namespace",
+		"Containers",
+		"CPURequest",
+		"RecomendedCPURequest",
+		"ReclaimableCPU",
+		"MemoryRequest",
+		"RecomendedMemoryRequest",
+		"ReclaimableMemory",
+	}
+
+	if config.Get().Cost != nil {
+		header = append(header, "MonthlySavings")
+	}
+
+	summaryTable := &table{header: header}
+
+	namespaces, cluster := summary.Get(pods)
+
+	for _, totals := range append(namespaces, cluster) {
+		item := []string{
+			totals.Name,
+			strconv.Itoa(totals.Containers),
+			types.FormatResource(types.CPUResourcePlaningType, totals.CPURequest),
+			types.FormatResource(types.CPUResourcePlaningType, totals.RecomendedCPURequest),
+			types.FormatResource(types.CPUResourcePlaningType, totals.ReclaimableCPU),
+			types.FormatResource(types.MemoryResourcePlaningType, totals.MemoryRequest),
+			types.FormatResource(types.MemoryResourcePlaningType, totals.RecomendedMemoryRequest),
+			types.FormatResource(types.MemoryResourcePlaningType, totals.ReclaimableMemory),
+		}
+
+		if config.Get().Cost != nil {
+			item = append(item, fmt.Sprintf("%.2f", totals.MonthlySavings))
+		}
+
+		summaryTable.rows = append(summaryTable.rows, tableRow{cells: item})
+	}
+
+	return summaryTable
+}
@@ -0,0 +1,122 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// Table of report that can be rendered as text, markdown or html.
+type table struct {
+	header []string
+	rows   []tableRow
+}
+
+type tableRow struct {
+	pod   *types.PodResources // pod of row, nil for rows of totals
+	cells []string
+}
+
+func (t *table) writeText(out io.Writer) {
+	w := tabwriter.NewWriter(out, 0, 0, 1, ' ', tabwriter.Debug)
+
+	fmt.Fprintln(w, strings.Join(t.header, "\t"))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(w, strings.Join(row.cells, "\t"))
+	}
+
+	w.Flush()
+}
+
+// byNamespace returns tables with rows of every namespace, order of rows is kept.
+func (t *table) byNamespace() ([]string, map[string]*table) {
+	namespaces := make([]string, 0)
+	tables := make(map[string]*table)
+
+	for _, row := range t.rows {
+		namespace := ""
+		if row.pod != nil {
+			namespace = row.pod.Namespace
+		}
+
+		namespaceTable, ok := tables[namespace]
+		if !ok {
+			namespaceTable = &table{header: t.header}
+			tables[namespace] = namespaceTable
+			namespaces = append(namespaces, namespace)
+		}
+
+		namespaceTable.rows = append(namespaceTable.rows, row)
+	}
+
+	sort.Strings(namespaces)
+
+	return namespaces, tables
+}
+
+func (t *table) writeMarkdown(out io.Writer) {
+	escape := func(cells []string) string {
+		result := make([]string, 0, len(cells))
+
+		for _, cell := range cells {
+			result = append(result, strings.ReplaceAll(cell, "|", "\\|"))
+		}
+
+		return "| " + strings.Join(result, " | ") + " |"
+	}
+
+	fmt.Fprintln(out, escape(t.header))
+	fmt.Fprintln(out, "|"+strings.Repeat(" --- |", len(t.header)))
+
+	for _, row := range t.rows {
+		fmt.Fprintln(out, escape(row.cells))
+	}
+}
+
+// selectColumns returns table with columns in order, values of columns are values of fields
+// that are the same as in filters and sorting, cells of table are not used.
+func (t *table) selectColumns(columns []string) *table {
+	result := &table{header: columns}
+
+	for _, row := range t.rows {
+		cells := make([]string, 0, len(columns))
+
+		for _, column := range columns {
+			cells = append(cells, getColumnValue(row.pod, column))
+		}
+
+		result.rows = append(result.rows, tableRow{pod: row.pod, cells: cells})
+	}
+
+	return result
+}
+
+func getColumnValue(pod *types.PodResources, column string) string {
+	switch column {
+	case types.ColumnHPA:
+		return formatHPA(pod)
+	case types.ColumnDebug:
+		return pod.String()
+	}
+
+	value, _ := pod.GetFieldValue(column)
+
+	return value
+}
@@ -0,0 +1,114 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func testTable() *table {
+	row := func(namespace, name, memory string) tableRow {
+		return tableRow{
+			pod:   &types.PodResources{Namespace: namespace, PodName: name, MemoryRequest: memory},
+			cells: []string{name, memory},
+		}
+	}
+
+	return &table{
+		header: []string{"PodName", "MemoryRequest"},
+		rows: []tableRow{
+			row("b", "b-1", "1Gi"),
+			row("a", "a|1", "512Mi"),
+			row("b", "b-2", "100Mi"),
+		},
+	}
+}
+
+func TestByNamespace(t *testing.T) {
+	t.Parallel()
+
+	namespaces, tables := testTable().byNamespace()
+
+	if got := strings.Join(namespaces, ","); got != "a,b" {
+		t.Fatalf("want namespaces a,b, got %s", got)
+	}
+
+	rows := tables["b"].rows
+
+	if len(rows) != 2 || rows[0].cells[0] != "b-1" || rows[1].cells[0] != "b-2" {
+		t.Fatalf("want rows b-1,b-2 in order, got %v", rows)
+	}
+}
+
+func TestWriteMarkdown(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	testTable().writeMarkdown(&b)
+
+	want := `| PodName | MemoryRequest |
+| --- | --- |
+| b-1 | 1Gi |
+| a\|1 | 512Mi |
+| b-2 | 100Mi |
+`
+
+	if b.String() != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, b.String())
+	}
+}
+
+func TestSortValue(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"1Gi / 512Mi OK": "1073741824",
+		"500m":           "0.5",
+		"33%":            "33",
+		"default/app-1":  "",
+		"":               "",
+	}
+
+	for cell, want := range tests {
+		if got := sortValue(cell); got != want {
+			t.Fatalf("%q: want %q, got %q", cell, want, got)
+		}
+	}
+}
+
+func TestSelectColumns(t *testing.T) {
+	t.Parallel()
+
+	pod := &types.PodResources{Namespace: "default", PodName: "app-1", NodeName: "node-1", Evicted: true}
+
+	podsTable := &table{
+		header: []string{"PodName"},
+		rows:   []tableRow{{pod: pod, cells: []string{"default/app-1 Evicted"}}},
+	}
+
+	result := podsTable.selectColumns([]string{"NodeName", "PodName", "HPA"})
+
+	if got := strings.Join(result.header, ","); got != "NodeName,PodName,HPA" {
+		t.Fatalf("want header NodeName,PodName,HPA, got %s", got)
+	}
+
+	// values of fields are used instead of cells of table
+	if got := strings.Join(result.rows[0].cells, ","); got != "node-1,app-1," {
+		t.Fatalf("want cells node-1,app-1, got %s", got)
+	}
+}
@@ -0,0 +1,54 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bytes"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/summary"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/templates"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// Data of output template.
+type templateData struct {
+	// pods after sorting and -top
+	Pods []*types.PodResources
+	// totals of all pods by namespace and in cluster
+	Namespaces []*summary.Totals
+	Cluster    *summary.Totals
+}
+
+// writeTemplate writes pods report with user template.
+func writeTemplate(b *bytes.Buffer, pods []*types.PodResources) error {
+	outputTemplate, err := templates.Parse(*config.Get().Output)
+	if err != nil {
+		return errors.Wrap(err, "error parsing output template")
+	}
+
+	_, rows, err := getPodsTable(pods)
+	if err != nil {
+		return err
+	}
+
+	data := templateData{Pods: rows}
+	data.Namespaces, data.Cluster = summary.Get(pods)
+
+	if err := outputTemplate.Execute(b, data); err != nil {
+		return errors.Wrap(err, "error executing output template")
+	}
+
+	return nil
+}
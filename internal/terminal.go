@@ -0,0 +1,148 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bufio"
+	"strings"
+	"unicode/utf8"
+)
+
+// names of special keys of terminal.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyPageUp    = "pgup"
+	keyPageDown  = "pgdown"
+	keyHome      = "home"
+	keyEnd       = "end"
+	keyEnter     = "enter"
+	keyEscape    = "esc"
+	keyBackspace = "backspace"
+	keyTab       = "tab"
+	keyCtrlC     = "ctrl-c"
+)
+
+// runes of control keys in raw mode.
+const (
+	runeCtrlC  = '\x03'
+	runeDelete = '\x7f'
+)
+
+// escape sequences of terminal.
+const (
+	terminalAltScreen  = "\x1b[?1049h\x1b[?25l"
+	terminalMainScreen = "\x1b[?25h\x1b[?1049l"
+	terminalHome       = "\x1b[H"
+	terminalClearLine  = "\x1b[K"
+	terminalClearDown  = "\x1b[J"
+	terminalReverse    = "\x1b[7m"
+	terminalBold       = "\x1b[1m"
+	terminalReset      = "\x1b[0m"
+)
+
+// escape sequences of special keys without leading ESC [.
+var escapeKeys = map[string]string{
+	"A":  keyUp,
+	"B":  keyDown,
+	"C":  keyRight,
+	"D":  keyLeft,
+	"H":  keyHome,
+	"F":  keyEnd,
+	"1~": keyHome,
+	"4~": keyEnd,
+	"5~": keyPageUp,
+	"6~": keyPageDown,
+}
+
+// readKey reads key from terminal in raw mode, special keys are returned by name,
+// other keys as typed rune, unknown escape sequences are returned as empty string.
+func readKey(reader *bufio.Reader) (string, error) {
+	r, _, err := reader.ReadRune()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	switch r {
+	case '\r', '\n':
+		return keyEnter, nil
+	case '\t':
+		return keyTab, nil
+	case runeDelete, '\b':
+		return keyBackspace, nil
+	case runeCtrlC:
+		return keyCtrlC, nil
+	case '\x1b':
+		// escape key is not followed by sequence
+		if reader.Buffered() == 0 {
+			return keyEscape, nil
+		}
+
+		return readEscapeKey(reader)
+	}
+
+	return string(r), nil
+}
+
+func readEscapeKey(reader *bufio.Reader) (string, error) {
+	next, err := reader.ReadByte()
+	if err != nil {
+		return "", err //nolint:wrapcheck
+	}
+
+	if next != '[' && next != 'O' {
+		return keyEscape, nil
+	}
+
+	var sequence strings.Builder
+
+	for {
+		c, err := reader.ReadByte()
+		if err != nil {
+			return "", err //nolint:wrapcheck
+		}
+
+		sequence.WriteByte(c)
+
+		// final byte of control sequence
+		if c >= 0x40 && c <= 0x7e {
+			break
+		}
+	}
+
+	return escapeKeys[sequence.String()], nil
+}
+
+// truncate returns text with width of terminal columns or less.
+func truncate(text string, width int) string {
+	if width <= 0 {
+		return ""
+	}
+
+	if utf8.RuneCountInString(text) <= width {
+		return text
+	}
+
+	return string([]rune(text)[:width])
+}
+
+// pad returns text with spaces up to width.
+func pad(text string, width int) string {
+	if count := utf8.RuneCountInString(text); count < width {
+		return text + strings.Repeat(" ", width-count)
+	}
+
+	return text
+}
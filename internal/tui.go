@@ -0,0 +1,685 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/api"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/filter"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/patch"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	"golang.org/x/term"
+)
+
+// Grouping of rows in tui.
+type tuiGroupBy string
+
+const (
+	tuiGroupByNone      = tuiGroupBy("none")
+	tuiGroupByNamespace = tuiGroupBy("namespace")
+	tuiGroupByWorkload  = tuiGroupBy("workload")
+)
+
+// Input line of tui.
+type tuiInput string
+
+const (
+	tuiInputNone   = tuiInput("")
+	tuiInputFilter = tuiInput("filter")
+	tuiInputSort   = tuiInput("sort-by")
+)
+
+const tuiHelp = "↑↓ move  ←→ columns  / filter  s sort  n namespaces  w workloads  enter expand  d details  m mark  e export  q quit" //nolint:lll
+
+// default size of terminal if size is unknown.
+const (
+	tuiDefaultWidth  = 80
+	tuiDefaultHeight = 24
+)
+
+// Line of tui, header of group or row of table.
+type tuiLine struct {
+	group string
+	rows  []tableRow // rows of group
+	row   *tableRow
+}
+
+type tui struct {
+	table    *table     // all rows of report
+	rows     []tableRow // rows after filter and sort
+	lines    []tuiLine  // visible lines
+	cursor   int
+	offset   int // first visible line
+	column   int // first visible column
+	groupBy  tuiGroupBy
+	expanded map[string]bool
+	marked   map[*types.PodResources]bool
+	detail   bool
+	input    tuiInput
+	value    string // value of input line
+	previous string // value of input line before editing
+	filter   string
+	sortBy   string
+	status   string
+	width    int
+	height   int
+}
+
+// TUI shows results in interactive terminal ui.
+func TUI() error {
+	if !term.IsTerminal(int(os.Stdin.Fd())) || !term.IsTerminal(int(os.Stdout.Fd())) {
+		return errors.New("tui requires terminal")
+	}
+
+	// details pane shows explain of recomendations
+	recomender.ForceExplain = true
+
+	pods, err := api.GetPodResources()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	if len(pods) == 0 {
+		return errors.New("no pods found")
+	}
+
+	podsTable, _, err := getPodsTable(pods)
+	if err != nil {
+		return err
+	}
+
+	state, err := term.MakeRaw(int(os.Stdin.Fd()))
+	if err != nil {
+		return errors.Wrap(err, "error making raw terminal")
+	}
+
+	defer term.Restore(int(os.Stdin.Fd()), state) //nolint:errcheck
+
+	fmt.Fprint(os.Stdout, terminalAltScreen)
+	defer fmt.Fprint(os.Stdout, terminalMainScreen)
+
+	ui := newTUI(podsTable)
+	reader := bufio.NewReader(os.Stdin)
+
+	for {
+		ui.width, ui.height = tuiDefaultWidth, tuiDefaultHeight
+
+		if width, height, err := term.GetSize(int(os.Stdout.Fd())); err == nil {
+			ui.width, ui.height = width, height
+		}
+
+		ui.render(os.Stdout)
+
+		key, err := readKey(reader)
+		if err != nil {
+			return errors.Wrap(err, "error reading key")
+		}
+
+		if !ui.handleKey(key) {
+			return nil
+		}
+	}
+}
+
+func newTUI(podsTable *table) *tui {
+	ui := &tui{
+		table:    podsTable,
+		groupBy:  tuiGroupByNone,
+		expanded: make(map[string]bool),
+		marked:   make(map[*types.PodResources]bool),
+		sortBy:   *config.Get().SortBy,
+	}
+
+	ui.rows = podsTable.rows
+	ui.status = tuiHelp
+	ui.update()
+
+	return ui
+}
+
+// handleKey changes state of tui by key, returns false to quit.
+func (t *tui) handleKey(key string) bool {
+	if t.input != tuiInputNone {
+		t.handleInputKey(key)
+
+		return true
+	}
+
+	t.status = tuiHelp
+
+	switch key {
+	case "q", keyCtrlC:
+		return false
+	case keyUp, "k":
+		t.move(-1)
+	case keyDown, "j":
+		t.move(1)
+	case keyPageUp:
+		t.move(-t.pageSize())
+	case keyPageDown:
+		t.move(t.pageSize())
+	case keyHome:
+		t.move(-len(t.lines))
+	case keyEnd:
+		t.move(len(t.lines))
+	case keyLeft, "h":
+		t.column = max(t.column-1, 0)
+	case keyRight, "l":
+		t.column = min(t.column+1, len(t.table.header)-1)
+	case "/":
+		t.startInput(tuiInputFilter, t.filter)
+	case "s":
+		t.startInput(tuiInputSort, t.sortBy)
+	case "n":
+		t.setGroupBy(tuiGroupByNamespace)
+	case "w":
+		t.setGroupBy(tuiGroupByWorkload)
+	case keyEnter:
+		t.toggleExpanded()
+	case "d", keyTab:
+		t.detail = !t.detail
+	case "m", " ":
+		t.toggleMarked()
+	case "e":
+		t.export()
+	}
+
+	return true
+}
+
+func (t *tui) handleInputKey(key string) {
+	switch key {
+	case keyEnter:
+		// input is not closed while value has errors
+		if err := t.apply(t.input, t.value); err != nil {
+			t.status = err.Error()
+
+			return
+		}
+
+		t.input = tuiInputNone
+		t.status = tuiHelp
+
+		return
+	case keyEscape, keyCtrlC:
+		t.value = t.previous
+	case keyBackspace:
+		if runes := []rune(t.value); len(runes) > 0 {
+			t.value = string(runes[:len(runes)-1])
+		}
+	default:
+		if len([]rune(key)) != 1 {
+			return
+		}
+
+		t.value += key
+	}
+
+	// results are changed while typing
+	if err := t.apply(t.input, t.value); err != nil {
+		t.status = err.Error()
+	} else {
+		t.status = ""
+	}
+
+	if key == keyEscape || key == keyCtrlC {
+		t.input = tuiInputNone
+		t.status = tuiHelp
+	}
+}
+
+func (t *tui) startInput(input tuiInput, value string) {
+	t.input = input
+	t.value = value
+	t.previous = value
+	t.status = ""
+}
+
+// apply sets filter or sorting of results.
+func (t *tui) apply(input tuiInput, value string) error {
+	filterText, sortBy := t.filter, t.sortBy
+
+	switch input {
+	case tuiInputFilter:
+		filterText = value
+	case tuiInputSort:
+		sortBy = value
+	case tuiInputNone:
+	}
+
+	rows, err := filterRows(t.table.rows, filterText)
+	if err != nil {
+		return err
+	}
+
+	if err := sortRows(rows, sortBy); err != nil {
+		return err
+	}
+
+	t.rows, t.filter, t.sortBy = rows, filterText, sortBy
+	t.update()
+
+	return nil
+}
+
+func filterRows(rows []tableRow, text string) ([]tableRow, error) {
+	if len(strings.TrimSpace(text)) == 0 {
+		return append([]tableRow{}, rows...), nil
+	}
+
+	expression, err := filter.Parse(text, types.GetFieldNames())
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing filter")
+	}
+
+	result := make([]tableRow, 0)
+
+	for _, row := range rows {
+		match, err := expression.Match(row.pod.GetFieldValue)
+		if err != nil {
+			return nil, errors.Wrap(err, "error filtering")
+		}
+
+		if match {
+			result = append(result, row)
+		}
+	}
+
+	return result, nil
+}
+
+// sortRows sorts rows of pods with same keys as -sort-by.
+func sortRows(rows []tableRow, sortBy string) error {
+	keys, err := types.ParseSortBy(sortBy)
+	if err != nil {
+		return errors.Wrap(err, "error parsing sort-by")
+	}
+
+	pods := make([]*types.PodResources, 0, len(rows))
+	podRows := make(map[*types.PodResources]tableRow, len(rows))
+
+	for _, row := range rows {
+		pods = append(pods, row.pod)
+		podRows[row.pod] = row
+	}
+
+	types.SortPodResources(pods, keys)
+
+	for i, pod := range pods {
+		rows[i] = podRows[pod]
+	}
+
+	return nil
+}
+
+func (t *tui) setGroupBy(groupBy tuiGroupBy) {
+	if t.groupBy == groupBy {
+		groupBy = tuiGroupByNone
+	}
+
+	t.groupBy = groupBy
+	t.expanded = make(map[string]bool)
+	t.cursor = 0
+	t.update()
+}
+
+func (t *tui) groupName(pod *types.PodResources) string {
+	switch t.groupBy {
+	case tuiGroupByNamespace:
+		return pod.Namespace
+	case tuiGroupByWorkload:
+		return pod.GetWorkloadName()
+	case tuiGroupByNone:
+	}
+
+	return ""
+}
+
+// update builds visible lines from rows, groups are in order of first row.
+func (t *tui) update() {
+	t.lines = make([]tuiLine, 0, len(t.rows))
+
+	if t.groupBy == tuiGroupByNone {
+		for i := range t.rows {
+			t.lines = append(t.lines, tuiLine{row: &t.rows[i]})
+		}
+	} else {
+		groups := make([]string, 0)
+		groupRows := make(map[string][]tableRow)
+
+		for _, row := range t.rows {
+			name := t.groupName(row.pod)
+
+			if _, ok := groupRows[name]; !ok {
+				groups = append(groups, name)
+			}
+
+			groupRows[name] = append(groupRows[name], row)
+		}
+
+		for _, name := range groups {
+			t.lines = append(t.lines, tuiLine{group: name, rows: groupRows[name]})
+
+			if t.expanded[name] {
+				rows := groupRows[name]
+
+				for i := range rows {
+					t.lines = append(t.lines, tuiLine{row: &rows[i]})
+				}
+			}
+		}
+	}
+
+	t.move(0)
+}
+
+func (t *tui) move(delta int) {
+	t.cursor = max(min(t.cursor+delta, len(t.lines)-1), 0)
+}
+
+func (t *tui) selected() *tuiLine {
+	if t.cursor < len(t.lines) {
+		return &t.lines[t.cursor]
+	}
+
+	return nil
+}
+
+func (t *tui) toggleExpanded() {
+	line := t.selected()
+	if line == nil {
+		return
+	}
+
+	if line.row != nil {
+		t.detail = !t.detail
+
+		return
+	}
+
+	t.expanded[line.group] = !t.expanded[line.group]
+	t.update()
+}
+
+// toggleMarked marks row for patch export, all rows of group are marked on group line.
+func (t *tui) toggleMarked() {
+	line := t.selected()
+	if line == nil {
+		return
+	}
+
+	rows := line.rows
+	if line.row != nil {
+		rows = []tableRow{*line.row}
+	}
+
+	marked := t.countMarked(rows) < len(rows)
+
+	for _, row := range rows {
+		if marked {
+			t.marked[row.pod] = true
+		} else {
+			delete(t.marked, row.pod)
+		}
+	}
+
+	t.move(1)
+}
+
+func (t *tui) countMarked(rows []tableRow) int {
+	count := 0
+
+	for _, row := range rows {
+		if t.marked[row.pod] {
+			count++
+		}
+	}
+
+	return count
+}
+
+// export writes kubectl commands with recomendations of marked rows, rows without
+// confident recomendation are skipped (recomendations are exported with -force).
+func (t *tui) export() {
+	pods := make([]*types.PodResources, 0, len(t.marked))
+
+	for _, row := range t.table.rows {
+		if t.marked[row.pod] {
+			pods = append(pods, row.pod)
+		}
+	}
+
+	if len(pods) == 0 {
+		t.status = "no marked rows, mark rows with m"
+
+		return
+	}
+
+	patches, skipped := patch.Build(pods, recomender.IsConfident)
+
+	var b bytes.Buffer
+
+	for _, item := range skipped {
+		fmt.Fprintf(&b, "# skipped %s %s: %s\n", item.Pod.GetPodNamespaceName(), item.Pod.ContainerName, item.Reason)
+	}
+
+	if err := patch.Write(&b, patches); err != nil {
+		t.status = err.Error()
+
+		return
+	}
+
+	const filePermission = 0o755
+
+	fileName := *config.Get().TUIPatchFile
+
+	if err := os.WriteFile(fileName, b.Bytes(), os.FileMode(filePermission)); err != nil {
+		t.status = fmt.Sprintf("error writing %s: %s", fileName, err.Error())
+
+		return
+	}
+
+	t.status = fmt.Sprintf("%d workloads exported to %s, %d containers skipped", len(patches), fileName, len(skipped))
+}
+
+func (t *tui) pageSize() int {
+	return max(t.tableHeight()-1, 1)
+}
+
+// tableHeight returns number of lines of table without header.
+func (t *tui) tableHeight() int {
+	// title, header and status lines
+	const reserved = 3
+
+	height := t.height - reserved
+
+	if t.detail {
+		height -= t.detailHeight() + 1
+	}
+
+	return max(height, 1)
+}
+
+func (t *tui) detailHeight() int {
+	const detailDivisor = 2
+
+	return t.height / detailDivisor
+}
+
+func (t *tui) render(out io.Writer) {
+	var b bytes.Buffer
+
+	b.WriteString(terminalHome)
+
+	// text of line must be truncated to width of terminal
+	writeLine := func(text string) {
+		b.WriteString(text)
+		b.WriteString(terminalClearLine + "\r\n")
+	}
+
+	title := fmt.Sprintf("k8s-resources-cli | %d/%d rows | filter: %s | sort-by: %s | group: %s | marked: %d",
+		len(t.rows), len(t.table.rows), t.filter, t.sortBy, t.groupBy, len(t.marked))
+
+	writeLine(terminalBold + truncate(title, t.width) + terminalReset)
+
+	widths := t.columnWidths()
+
+	writeLine(terminalBold + truncate("  "+t.formatCells(t.table.header, widths), t.width) + terminalReset)
+
+	height := t.tableHeight()
+
+	// keep cursor visible
+	if t.cursor < t.offset {
+		t.offset = t.cursor
+	}
+
+	if t.cursor >= t.offset+height {
+		t.offset = t.cursor - height + 1
+	}
+
+	for i := t.offset; i < t.offset+height; i++ {
+		if i >= len(t.lines) {
+			writeLine("")
+
+			continue
+		}
+
+		text := truncate(t.formatLine(t.lines[i], widths), t.width)
+
+		if i == t.cursor {
+			text = terminalReverse + pad(text, t.width) + terminalReset
+		}
+
+		writeLine(text)
+	}
+
+	if t.detail {
+		writeLine(strings.Repeat("─", t.width))
+
+		details := strings.Split(t.details(), "\n")
+
+		for i := 0; i < t.detailHeight(); i++ {
+			if i < len(details) {
+				writeLine(truncate(details[i], t.width))
+			} else {
+				writeLine("")
+			}
+		}
+	}
+
+	if t.input != tuiInputNone {
+		text := fmt.Sprintf("%s: %s", t.input, t.value)
+
+		if len(t.status) > 0 {
+			text += "  (" + t.status + ")"
+		}
+
+		b.WriteString(truncate(text, t.width))
+	} else {
+		b.WriteString(truncate(t.status, t.width))
+	}
+
+	b.WriteString(terminalClearDown)
+
+	out.Write(b.Bytes()) //nolint:errcheck
+}
+
+// columnWidths returns widths of columns of all filtered rows, widths are not changed while scrolling.
+func (t *tui) columnWidths() []int {
+	widths := make([]int, len(t.table.header))
+
+	for i, name := range t.table.header {
+		widths[i] = len([]rune(name))
+	}
+
+	for _, row := range t.rows {
+		for i, cell := range row.cells {
+			widths[i] = max(widths[i], len([]rune(cell)))
+		}
+	}
+
+	return widths
+}
+
+func (t *tui) formatCells(cells []string, widths []int) string {
+	result := make([]string, 0, len(cells))
+
+	for i := t.column; i < len(cells); i++ {
+		result = append(result, pad(cells[i], widths[i]))
+	}
+
+	return strings.Join(result, " | ")
+}
+
+func (t *tui) formatLine(line tuiLine, widths []int) string {
+	if line.row == nil {
+		arrow := "▸"
+		if t.expanded[line.group] {
+			arrow = "▾"
+		}
+
+		return fmt.Sprintf("%s %s (%d containers, %d marked)", arrow, line.group, len(line.rows), t.countMarked(line.rows))
+	}
+
+	mark := " "
+	if t.marked[line.row.pod] {
+		mark = "*"
+	}
+
+	indent := ""
+	if t.groupBy != tuiGroupByNone {
+		indent = "  "
+	}
+
+	return mark + " " + indent + t.formatCells(line.row.cells, widths)
+}
+
+// details returns explain of selected container.
+func (t *tui) details() string {
+	line := t.selected()
+	if line == nil {
+		return ""
+	}
+
+	var b bytes.Buffer
+
+	if line.row == nil {
+		fmt.Fprintf(&b, "%s: %d containers, %d marked", line.group, len(line.rows), t.countMarked(line.rows))
+
+		return b.String()
+	}
+
+	pod := line.row.pod
+	resources := pod.GetFormattedResources()
+
+	fmt.Fprintf(&b, "%s/%s (%s)\n", pod.GetPodNamespaceName(), pod.ContainerName, pod.GetWorkloadName())
+	fmt.Fprintf(&b, "  memory request: %s, limit: %s\n", resources.MemoryRequest, resources.MemoryLimit)
+	fmt.Fprintf(&b, "  cpu request: %s, limit: %s\n", resources.CPURequest, resources.CPULimit)
+
+	if confidence := formatConfidence(pod); len(confidence) > 0 {
+		fmt.Fprintf(&b, "  confidence: %s\n", confidence)
+	}
+
+	writePodExplain(&b, pod)
+
+	return strings.TrimSuffix(b.String(), "\n")
+}
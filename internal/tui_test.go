@@ -0,0 +1,213 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package internal
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func testTUI() *tui {
+	row := func(namespace, name, template, ownerKind, memory string) tableRow {
+		return tableRow{
+			pod: &types.PodResources{
+				Namespace:     namespace,
+				PodName:       name,
+				PodTemplate:   template,
+				OwnerKind:     ownerKind,
+				OwnerName:     strings.TrimSuffix(template, "-"),
+				MemoryRequest: memory,
+			},
+			cells: []string{name, memory},
+		}
+	}
+
+	return newTUI(&table{
+		header: []string{"PodName", "MemoryRequest"},
+		rows: []tableRow{
+			row("b", "app-1", "app-", "ReplicaSet", "1Gi"),
+			row("a", "web-1", "web-", "ReplicaSet", "512Mi"),
+			row("b", "app-2", "app-", "ReplicaSet", "100Mi"),
+			row("b", "db-0", "db-", "StatefulSet", "2Gi"),
+		},
+	})
+}
+
+// typeKeys sends every character of text as key.
+func typeKeys(ui *tui, text string) {
+	for _, key := range text {
+		ui.handleKey(string(key))
+	}
+}
+
+// lineNames returns visible lines, groups are in brackets.
+func lineNames(ui *tui) string {
+	names := make([]string, 0, len(ui.lines))
+
+	for _, line := range ui.lines {
+		if line.row == nil {
+			names = append(names, "["+line.group+"]")
+		} else {
+			names = append(names, line.row.pod.PodName)
+		}
+	}
+
+	return strings.Join(names, ",")
+}
+
+func TestTUIFilter(t *testing.T) {
+	t.Parallel()
+
+	ui := testTUI()
+
+	ui.handleKey("/")
+	typeKeys(ui, ".Namespace==b")
+
+	// results are filtered while typing
+	if got := lineNames(ui); got != "app-1,app-2,db-0" {
+		t.Fatalf("want app-1,app-2,db-0, got %s", got)
+	}
+
+	ui.handleKey(keyEnter)
+
+	if ui.input != tuiInputNone || ui.filter != ".Namespace==b" {
+		t.Fatalf("want closed input with filter, got %q %q", ui.input, ui.filter)
+	}
+
+	// input with error is not closed
+	ui.handleKey("/")
+	typeKeys(ui, " &&")
+	ui.handleKey(keyEnter)
+
+	if ui.input != tuiInputFilter || len(ui.status) == 0 {
+		t.Fatalf("want open input with error, got %q %q", ui.input, ui.status)
+	}
+
+	// escape restores previous filter
+	ui.handleKey(keyEscape)
+
+	if ui.input != tuiInputNone || ui.filter != ".Namespace==b" || lineNames(ui) != "app-1,app-2,db-0" {
+		t.Fatalf("want previous filter, got %q %s", ui.filter, lineNames(ui))
+	}
+
+	ui.handleKey("/")
+
+	for range ui.value {
+		ui.handleKey(keyBackspace)
+	}
+
+	ui.handleKey(keyEnter)
+
+	if got := lineNames(ui); got != "app-1,web-1,app-2,db-0" {
+		t.Fatalf("want all rows without filter, got %s", got)
+	}
+}
+
+func TestTUISort(t *testing.T) {
+	t.Parallel()
+
+	ui := testTUI()
+
+	ui.handleKey("s")
+	typeKeys(ui, "MemoryRequest:desc")
+	ui.handleKey(keyEnter)
+
+	if got := lineNames(ui); got != "db-0,app-1,web-1,app-2" {
+		t.Fatalf("want rows sorted by memory, got %s", got)
+	}
+
+	ui.handleKey("s")
+	typeKeys(ui, ",Unknown")
+	ui.handleKey(keyEnter)
+
+	if ui.input != tuiInputSort || ui.sortBy != "MemoryRequest:desc" {
+		t.Fatalf("want open input with previous sorting, got %q %q", ui.input, ui.sortBy)
+	}
+}
+
+func TestTUIGroups(t *testing.T) {
+	t.Parallel()
+
+	ui := testTUI()
+
+	ui.handleKey("n")
+
+	if got := lineNames(ui); got != "[b],[a]" {
+		t.Fatalf("want collapsed namespaces, got %s", got)
+	}
+
+	ui.handleKey(keyEnter)
+
+	if got := lineNames(ui); got != "[b],app-1,app-2,db-0,[a]" {
+		t.Fatalf("want expanded namespace b, got %s", got)
+	}
+
+	ui.handleKey(keyEnter)
+
+	if got := lineNames(ui); got != "[b],[a]" {
+		t.Fatalf("want collapsed namespace b, got %s", got)
+	}
+
+	ui.handleKey("w")
+
+	if got := lineNames(ui); got != "[b/Deployment/app],[a/Deployment/web],[b/StatefulSet/db]" {
+		t.Fatalf("want collapsed workloads, got %s", got)
+	}
+
+	// same key removes grouping
+	ui.handleKey("w")
+
+	if got := lineNames(ui); got != "app-1,web-1,app-2,db-0" {
+		t.Fatalf("want rows without groups, got %s", got)
+	}
+}
+
+func TestTUIMark(t *testing.T) {
+	t.Parallel()
+
+	ui := testTUI()
+
+	ui.handleKey("w")
+	ui.handleKey("m")
+
+	// all rows of group are marked and cursor is moved to next line
+	if len(ui.marked) != 2 || !ui.marked[ui.table.rows[0].pod] || !ui.marked[ui.table.rows[2].pod] || ui.cursor != 1 {
+		t.Fatalf("want marked rows of app, got %v with cursor %d", ui.marked, ui.cursor)
+	}
+
+	ui.handleKey(keyUp)
+	ui.handleKey(keyEnter)
+	ui.handleKey(keyDown)
+	ui.handleKey("m")
+
+	// one row of marked group is unmarked
+	if len(ui.marked) != 1 || !ui.marked[ui.table.rows[2].pod] {
+		t.Fatalf("want marked app-2, got %v", ui.marked)
+	}
+
+	ui.handleKey(keyHome)
+	ui.handleKey(" ")
+
+	if len(ui.marked) != 2 {
+		t.Fatalf("want marked group with partly marked rows, got %v", ui.marked)
+	}
+
+	ui.handleKey(keyHome)
+	ui.handleKey("m")
+
+	if len(ui.marked) != 0 {
+		t.Fatalf("want unmarked group, got %v", ui.marked)
+	}
+}
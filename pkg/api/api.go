@@ -13,14 +13,15 @@ limitations under the License.
 package api
 
 import (
-	"bytes"
 	"context"
 	"fmt"
-	"html/template"
 	"strings"
+	"time"
 
 	"github.com/cheggaaa/pb"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/filter"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
 	"github.com/pkg/errors"
@@ -35,6 +36,8 @@ import (
 //nolint: gochecknoglobals
 var clientset *kubernetes.Clientset
 
+const oomKilledReason = "OOMKilled"
+
 func Init() error {
 	var (
 		kubeconfig *rest.Config
@@ -75,7 +78,30 @@ func GetPodResources() ([]*types.PodResources, error) { //nolint: funlen,cyclop,
 		return nil, errors.New("no pods found")
 	}
 
+	var filterExpression *filter.Expression
+
+	if len(*config.Get().Filter) > 0 {
+		filterExpression, err = filter.Parse(*config.Get().Filter, types.GetFieldNames())
+		if err != nil {
+			return nil, errors.Wrap(err, "error parsing filter")
+		}
+	}
+
+	// filter with recomendation fields can be applied only after recomendations are calculated
+	filterAfterRecomendations := filterExpression != nil && isRecomendationFilter(filterExpression)
+
+	nodePools, err := getNodePools()
+	if err != nil {
+		return nil, errors.Wrap(err, "error get node pools")
+	}
+
+	namespaces, err := getNamespaces()
+	if err != nil {
+		return nil, errors.Wrap(err, "error get namespaces")
+	}
+
 	results := make([]*types.PodResources, 0)
+	pendingFilter := make(map[*types.PodResources]bool)
 
 	for _, pod := range pods.Items {
 		containers := pod.Spec.Containers
@@ -84,19 +110,56 @@ func GetPodResources() ([]*types.PodResources, error) { //nolint: funlen,cyclop,
 			containers = append(containers, pod.Spec.InitContainers...)
 		}
 
-		for _, container := range containers {
+		for containerIndex, container := range containers {
 			item := types.PodResources{
 				PodName:       pod.Name,
 				PodTemplate:   pod.GenerateName,
 				ContainerName: container.Name,
 				Namespace:     pod.Namespace,
 				NodeName:      pod.Spec.NodeName,
+				NodePool:      nodePools[pod.Spec.NodeName],
 				MemoryRequest: container.Resources.Requests.Memory().String(),
 				MemoryLimit:   container.Resources.Limits.Memory().String(),
 				CPURequest:    container.Resources.Requests.Cpu().String(),
 				CPULimit:      container.Resources.Limits.Cpu().String(),
 				QoS:           string(pod.Status.QOSClass),
 				SafeToEvict:   false,
+				Labels:        pod.Labels,
+				InitContainer: containerIndex >= len(pod.Spec.Containers),
+				Phase:         string(pod.Status.Phase),
+				NodeSelector:  pod.Spec.NodeSelector,
+				Tolerations:   pod.Spec.Tolerations,
+			}
+
+			item.PodCPURequest, item.PodMemoryRequest = getPodRequests(pod)
+
+			// image of running container can differ from spec when pod is updated
+			item.Image = container.Image
+
+			if status := getContainerStatus(pod, container.Name); status != nil && len(status.Image) > 0 {
+				item.Image = status.Image
+			}
+
+			if owner := metav1.GetControllerOf(&pod); owner != nil {
+				item.OwnerKind = owner.Kind
+				item.OwnerName = owner.Name
+			}
+
+			item.HPA, err = getPodHPA(&item)
+			if err != nil {
+				return nil, errors.Wrap(err, "error get horizontal pod autoscaler")
+			}
+
+			item.LimitRange, err = getLimitRange(pod.Namespace)
+			if err != nil {
+				return nil, errors.Wrap(err, "error get limit range")
+			}
+
+			limitRanger := pod.Annotations[types.LimitRangerAnnotation]
+			item.LimitRangeDefaults = types.ParseLimitRangerDefaults(limitRanger, container.Name)
+
+			if namespace, ok := namespaces[pod.Namespace]; ok {
+				item.NamespaceLabels = namespace.Labels
 			}
 
 			podTemplateHash := pod.Labels["pod-template-hash"]
@@ -110,20 +173,29 @@ func GetPodResources() ([]*types.PodResources, error) { //nolint: funlen,cyclop,
 				item.SafeToEvict = true
 			}
 
-			if isContainerTerminatedReason(pod, container.Name, "OOMKilled") {
+			if isContainerTerminatedReason(pod, container.Name, oomKilledReason) {
 				item.OOMKilled = true
 			}
 
+			if err := addRestartHistory(pod, &item); err != nil {
+				return nil, errors.Wrap(err, "error get restart history")
+			}
+
 			if pod.Status.Reason == "Evicted" {
 				item.Evicted = true
 			}
 
 			showResult := false
-
-			if len(*config.Get().Filter) > 0 {
-				showResult, err = filterResult(item)
-				if err != nil {
-					return nil, errors.Wrap(err, "error filtering result")
+			filterPending := false
+
+			if filterExpression != nil {
+				if filterAfterRecomendations {
+					filterPending = true
+				} else {
+					showResult, err = filterExpression.Match(item.GetFieldValue)
+					if err != nil {
+						return nil, errors.Wrap(err, "error filtering result")
+					}
 				}
 			}
 
@@ -143,9 +215,13 @@ func GetPodResources() ([]*types.PodResources, error) { //nolint: funlen,cyclop,
 				showResult = true
 			}
 
-			if showResult {
+			if showResult || filterPending {
 				results = append(results, &item)
 			}
+
+			if !showResult && filterPending {
+				pendingFilter[&item] = true
+			}
 		}
 	}
 
@@ -153,9 +229,85 @@ func GetPodResources() ([]*types.PodResources, error) { //nolint: funlen,cyclop,
 		return nil, errors.Wrap(err, "error adding recommendations")
 	}
 
+	if filterAfterRecomendations {
+		results, err = filterResults(filterExpression, results, pendingFilter)
+		if err != nil {
+			return nil, errors.Wrap(err, "error filtering result")
+		}
+	}
+
 	return results, nil
 }
 
+// GetNodes returns allocatable resources of all nodes.
+func GetNodes() ([]*types.NodeResources, error) {
+	nodes, err := clientset.CoreV1().Nodes().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error get nodes")
+	}
+
+	result := make([]*types.NodeResources, 0, len(nodes.Items))
+
+	for _, node := range nodes.Items {
+		result = append(result, &types.NodeResources{
+			NodeName:          node.Name,
+			NodePool:          node.Labels[*config.Get().NodePoolLabel],
+			AllocatableCPU:    node.Status.Allocatable.Cpu().String(),
+			AllocatableMemory: node.Status.Allocatable.Memory().String(),
+			AllocatablePods:   node.Status.Allocatable.Pods().Value(),
+			Unschedulable:     node.Spec.Unschedulable,
+			Labels:            node.Labels,
+			Taints:            node.Spec.Taints,
+		})
+	}
+
+	return result, nil
+}
+
+// getNodePools returns node pool names by node name, nodes are needed only for cost model and nodes reports.
+func getNodePools() (map[string]string, error) {
+	result := make(map[string]string)
+
+	switch {
+	case config.Get().Cost != nil:
+	case *config.Get().Report == string(types.ReportTypeNodes):
+	case *config.Get().Report == string(types.ReportTypeBinpack):
+	default:
+		return result, nil
+	}
+
+	nodes, err := GetNodes()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, node := range nodes {
+		result[node.NodeName] = node.NodePool
+	}
+
+	return result, nil
+}
+
+// getNamespaces returns namespaces by name, namespaces are needed only for labels in chargeback report.
+func getNamespaces() (map[string]corev1.Namespace, error) {
+	result := make(map[string]corev1.Namespace)
+
+	if *config.Get().Report != string(types.ReportTypeChargeback) {
+		return result, nil
+	}
+
+	namespaces, err := clientset.CoreV1().Namespaces().List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		return nil, errors.Wrap(err, "error list namespaces")
+	}
+
+	for _, namespace := range namespaces.Items {
+		result[namespace.Name] = namespace
+	}
+
+	return result, nil
+}
+
 func calculateRecomendations(results []*types.PodResources) error {
 	if len(*config.Get().PrometheusURL) == 0 {
 		return nil
@@ -177,6 +329,34 @@ func calculateRecomendations(results []*types.PodResources) error {
 
 		results[i].SetRecomendation(recommend)
 
+		if *config.Get().ShowRestarts {
+			if err := recomender.AddOOMHistory(result); err != nil {
+				return errors.Wrap(err, "error get oom history")
+			}
+		}
+
+		if *config.Get().Seasonality {
+			if err := recomender.SetSeasonality(result); err != nil {
+				return errors.Wrap(err, "error get seasonality")
+			}
+
+			if *config.Get().SeasonalityRequests {
+				recomender.SetPeakRequests(result)
+			}
+		}
+
+		if *config.Get().ImageSplit {
+			if err := recomender.SetImageVersions(result); err != nil {
+				return errors.Wrap(err, "error get image versions")
+			}
+		}
+
+		if len(*config.Get().OOMRiskHorizon) > 0 {
+			if err := recomender.SetOOMRisk(result); err != nil {
+				return errors.Wrap(err, "error get oom risk")
+			}
+		}
+
 		bar.Increment()
 	}
 
@@ -184,58 +364,180 @@ func calculateRecomendations(results []*types.PodResources) error {
 		bar.Finish()
 	}
 
-	return nil
-}
+	// utilization of pod metrics of autoscalers needs recomendations of all containers of pod
+	podRequests := recomender.GetPodRequests(results)
 
-const conditionParts = 2
+	for _, result := range results {
+		if result.HPA != nil {
+			recomender.SetHPAEffect(result, podRequests[result.GetPodNamespaceName()])
+		}
 
-func filterResult(item types.PodResources) (bool, error) {
-	conditions := strings.Split(*config.Get().Filter, ",")
-	for _, condition := range conditions {
-		eq := strings.Split(condition, "==")
-		if len(eq) != conditionParts {
-			return false, errors.Errorf("invalid filter condition: %s, it must be .Field==value", condition)
+		if result.LimitRange != nil {
+			recomender.CheckLimitRange(result)
 		}
+	}
 
-		value, err := templateItem(eq[0], item)
-		if err != nil {
-			return false, errors.Wrap(err, "error template item")
+	usage, err := metrics.GetUsage()
+	if err != nil {
+		return errors.Wrap(err, "error get usage")
+	}
+
+	for _, result := range results {
+		if containerUsage, ok := usage[metrics.UsageKey(result.Namespace, result.PodName, result.ContainerName)]; ok {
+			result.Usage = containerUsage
 		}
+	}
 
-		if value == eq[1] {
-			return true, nil
+	return nil
+}
+
+func isRecomendationFilter(expression *filter.Expression) bool {
+	for _, name := range expression.Fields() {
+		field, err := types.GetField(name)
+		if err == nil && field.Recomendation {
+			return true
 		}
 	}
 
-	return false, nil
+	return false
 }
 
-func templateItem(value string, item types.PodResources) (string, error) {
-	tmpl, err := template.New("test").Parse(fmt.Sprintf("{{ %s }}", value))
-	if err != nil {
-		return "", errors.Wrap(err, "error parsing filter")
+// filterResults removes pending results that are not matched by filter expression.
+func filterResults(expression *filter.Expression, results []*types.PodResources, pending map[*types.PodResources]bool) ([]*types.PodResources, error) { //nolint:lll
+	filtered := make([]*types.PodResources, 0, len(results))
+
+	for _, result := range results {
+		if pending[result] {
+			match, err := expression.Match(result.GetFieldValue)
+			if err != nil {
+				return nil, err //nolint:wrapcheck
+			}
+
+			if !match {
+				continue
+			}
+		}
+
+		filtered = append(filtered, result)
 	}
 
-	var tpl bytes.Buffer
+	return filtered, nil
+}
+
+// getPodRequests returns sum of requests of containers of pod, init containers are not counted.
+func getPodRequests(pod corev1.Pod) (float64, float64) {
+	cpu, memory := 0.0, 0.0
 
-	err = tmpl.Execute(&tpl, item)
-	if err != nil {
-		return "", errors.Wrap(err, "error executing filter")
+	for _, container := range pod.Spec.Containers {
+		cpu += container.Resources.Requests.Cpu().AsApproximateFloat64()
+		memory += container.Resources.Requests.Memory().AsApproximateFloat64()
 	}
 
-	return tpl.String(), nil
+	return cpu, memory
+}
+
+func getContainerStatus(pod corev1.Pod, containerName string) *corev1.ContainerStatus {
+	for _, statuses := range [][]corev1.ContainerStatus{pod.Status.ContainerStatuses, pod.Status.InitContainerStatuses} {
+		for i := range statuses {
+			if statuses[i].Name == containerName {
+				return &statuses[i]
+			}
+		}
+	}
+
+	return nil
 }
 
 func isContainerTerminatedReason(pod corev1.Pod, containerName string, reason string) bool {
-	if len(pod.Status.ContainerStatuses) == 0 {
+	containerStatus := getContainerStatus(pod, containerName)
+	if containerStatus == nil {
 		return false
 	}
 
-	for _, containerStatus := range pod.Status.ContainerStatuses {
-		if containerStatus.Name == containerName {
-			return containerStatus.LastTerminationState.Terminated != nil && containerStatus.LastTerminationState.Terminated.Reason == reason //nolint:lll
+	return containerStatus.LastTerminationState.Terminated != nil && containerStatus.LastTerminationState.Terminated.Reason == reason //nolint:lll
+}
+
+// addRestartHistory adds restarts count and OOMKilled events from container status and kubernetes events.
+func addRestartHistory(pod corev1.Pod, item *types.PodResources) error {
+	containerStatus := getContainerStatus(pod, item.ContainerName)
+	if containerStatus == nil {
+		return nil
+	}
+
+	item.Restarts = int(containerStatus.RestartCount)
+
+	// pod spec is immutable, so current memory limit was in place at the time of OOMKilled
+	if terminated := containerStatus.LastTerminationState.Terminated; terminated != nil && terminated.Reason == oomKilledReason { //nolint:lll
+		item.AddOOMEvent(types.OOMEvent{
+			Time:        terminated.FinishedAt.Time,
+			MemoryLimit: item.MemoryLimit,
+			Source:      types.OOMEventSourceStatus,
+		}, 0)
+	}
+
+	if !*config.Get().ShowRestarts {
+		return nil
+	}
+
+	events, err := getOOMEvents(pod.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, event := range events {
+		if event.InvolvedObject.Name != pod.Name || !isContainerEvent(event, item.ContainerName) {
+			continue
+		}
+
+		eventTime := event.LastTimestamp.Time
+		if eventTime.IsZero() {
+			eventTime = event.EventTime.Time
 		}
+
+		item.AddOOMEvent(types.OOMEvent{
+			Time:        eventTime,
+			MemoryLimit: item.MemoryLimit,
+			Source:      types.OOMEventSourceEvent,
+		}, time.Minute)
 	}
 
-	return false
+	return nil
+}
+
+func isContainerEvent(event corev1.Event, containerName string) bool {
+	if len(event.InvolvedObject.FieldPath) == 0 {
+		return strings.Contains(event.Message, containerName)
+	}
+
+	return strings.HasSuffix(event.InvolvedObject.FieldPath, fmt.Sprintf("{%s}", containerName))
+}
+
+//nolint:gochecknoglobals
+var oomEventsCache = make(map[string][]corev1.Event)
+
+// getOOMEvents returns events of pods in namespace about OOMKilled containers,
+// kubernetes keeps events only for short period (1 hour by default).
+func getOOMEvents(namespace string) ([]corev1.Event, error) {
+	if events, ok := oomEventsCache[namespace]; ok {
+		return events, nil
+	}
+
+	events, err := clientset.CoreV1().Events(namespace).List(context.Background(), metav1.ListOptions{
+		FieldSelector: "involvedObject.kind=Pod",
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error list events")
+	}
+
+	result := make([]corev1.Event, 0)
+
+	for _, event := range events.Items {
+		if event.Reason == "OOMKilling" || strings.Contains(event.Message, oomKilledReason) {
+			result = append(result, event)
+		}
+	}
+
+	oomEventsCache[namespace] = result
+
+	return result, nil
 }
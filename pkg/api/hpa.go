@@ -0,0 +1,171 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	autoscalingv2 "k8s.io/api/autoscaling/v2"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//nolint:gochecknoglobals
+var (
+	hpaCache        = make(map[string][]autoscalingv2.HorizontalPodAutoscaler)
+	replicaSetCache = make(map[string]map[string]*metav1.OwnerReference)
+)
+
+// getWorkload returns kind and name of object that manages pod, deployment for pods of replica set.
+func getWorkload(namespace, ownerKind, ownerName string) (string, string, error) {
+	if ownerKind != "ReplicaSet" {
+		return ownerKind, ownerName, nil
+	}
+
+	if _, ok := replicaSetCache[namespace]; !ok {
+		replicaSets, err := clientset.AppsV1().ReplicaSets(namespace).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			return "", "", errors.Wrap(err, "error list replica sets")
+		}
+
+		owners := make(map[string]*metav1.OwnerReference)
+
+		for i := range replicaSets.Items {
+			owners[replicaSets.Items[i].Name] = metav1.GetControllerOf(&replicaSets.Items[i])
+		}
+
+		replicaSetCache[namespace] = owners
+	}
+
+	if owner := replicaSetCache[namespace][ownerName]; owner != nil {
+		return owner.Kind, owner.Name, nil
+	}
+
+	return ownerKind, ownerName, nil
+}
+
+func getHPAs(namespace string) ([]autoscalingv2.HorizontalPodAutoscaler, error) {
+	if hpas, ok := hpaCache[namespace]; ok {
+		return hpas, nil
+	}
+
+	hpas, err := clientset.AutoscalingV2().HorizontalPodAutoscalers(namespace).List(context.Background(), metav1.ListOptions{}) //nolint:lll
+	if err != nil {
+		return nil, errors.Wrap(err, "error list horizontal pod autoscalers")
+	}
+
+	hpaCache[namespace] = hpas.Items
+
+	return hpas.Items, nil
+}
+
+// getPodHPA returns horizontal pod autoscaler of pod workload, nil if workload is not scaled.
+func getPodHPA(item *types.PodResources) (*types.HPA, error) {
+	if !*config.Get().HPA || len(item.OwnerKind) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	hpas, err := getHPAs(item.Namespace)
+	if err != nil {
+		// tool can be used without permissions to read autoscalers
+		if apierrors.IsForbidden(err) {
+			log.WithError(err).Warn("horizontal pod autoscalers are not checked")
+
+			hpaCache[item.Namespace] = []autoscalingv2.HorizontalPodAutoscaler{}
+
+			return nil, nil //nolint:nilnil
+		}
+
+		return nil, err
+	}
+
+	if len(hpas) == 0 {
+		return nil, nil //nolint:nilnil
+	}
+
+	kind, name, err := getWorkload(item.Namespace, item.OwnerKind, item.OwnerName)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, hpa := range hpas {
+		if hpa.Spec.ScaleTargetRef.Kind != kind || hpa.Spec.ScaleTargetRef.Name != name {
+			continue
+		}
+
+		result := types.HPA{
+			Name:        hpa.Name,
+			MaxReplicas: hpa.Spec.MaxReplicas,
+			MinReplicas: 1,
+		}
+
+		if hpa.Spec.MinReplicas != nil {
+			result.MinReplicas = *hpa.Spec.MinReplicas
+		}
+
+		for _, metric := range hpa.Spec.Metrics {
+			utilization, resourceName, ok := getUtilizationTarget(metric, item.ContainerName)
+			if !ok {
+				continue
+			}
+
+			podMetric := metric.Type == autoscalingv2.ResourceMetricSourceType
+
+			switch resourceName {
+			case corev1.ResourceCPU:
+				result.TargetCPU = utilization
+				result.PodCPU = podMetric
+			case corev1.ResourceMemory:
+				result.TargetMemory = utilization
+				result.PodMemory = podMetric
+			}
+		}
+
+		return &result, nil
+	}
+
+	return nil, nil //nolint:nilnil
+}
+
+// getUtilizationTarget returns target utilization of resource metric of pod or container.
+func getUtilizationTarget(metric autoscalingv2.MetricSpec, containerName string) (int32, corev1.ResourceName, bool) {
+	var target autoscalingv2.MetricTarget
+
+	var resourceName corev1.ResourceName
+
+	switch {
+	case metric.Type == autoscalingv2.ResourceMetricSourceType && metric.Resource != nil:
+		target = metric.Resource.Target
+		resourceName = metric.Resource.Name
+	case metric.Type == autoscalingv2.ContainerResourceMetricSourceType && metric.ContainerResource != nil:
+		if metric.ContainerResource.Container != containerName {
+			return 0, "", false
+		}
+
+		target = metric.ContainerResource.Target
+		resourceName = metric.ContainerResource.Name
+	default:
+		return 0, "", false
+	}
+
+	if target.Type != autoscalingv2.UtilizationMetricType || target.AverageUtilization == nil {
+		return 0, "", false
+	}
+
+	return *target.AverageUtilization, resourceName, true
+}
@@ -0,0 +1,206 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package api
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+//nolint:gochecknoglobals
+var limitRangeCache = make(map[string]*types.LimitRange)
+
+// getLimitRange returns the strictest container constraints of LimitRanges in namespace,
+// nil if namespace has no LimitRanges.
+func getLimitRange(namespace string) (*types.LimitRange, error) {
+	if !*config.Get().LimitRange {
+		return nil, nil //nolint:nilnil
+	}
+
+	if result, ok := limitRangeCache[namespace]; ok {
+		return result, nil
+	}
+
+	limitRanges, err := clientset.CoreV1().LimitRanges(namespace).List(context.Background(), metav1.ListOptions{})
+	if err != nil {
+		// tool can be used without permissions to read limit ranges
+		if apierrors.IsForbidden(err) {
+			log.WithError(err).Warn("limit ranges are not checked")
+
+			limitRangeCache[namespace] = nil
+
+			return nil, nil //nolint:nilnil
+		}
+
+		return nil, errors.Wrap(err, "error list limit ranges")
+	}
+
+	var result *types.LimitRange
+
+	names := make([]string, 0)
+
+	for _, limitRange := range limitRanges.Items {
+		for _, limit := range limitRange.Spec.Limits {
+			if limit.Type != corev1.LimitTypeContainer {
+				continue
+			}
+
+			if result == nil {
+				result = &types.LimitRange{}
+			}
+
+			result.MinMemory = maxQuantity(result.MinMemory, limit.Min, corev1.ResourceMemory)
+			result.MinCPU = maxQuantity(result.MinCPU, limit.Min, corev1.ResourceCPU)
+			result.MaxMemory = minQuantity(result.MaxMemory, limit.Max, corev1.ResourceMemory)
+			result.MaxCPU = minQuantity(result.MaxCPU, limit.Max, corev1.ResourceCPU)
+			result.MaxMemoryRatio = minRatio(result.MaxMemoryRatio, limit.MaxLimitRequestRatio, corev1.ResourceMemory)
+			result.MaxCPURatio = minRatio(result.MaxCPURatio, limit.MaxLimitRequestRatio, corev1.ResourceCPU)
+
+			names = append(names, limitRange.Name)
+		}
+	}
+
+	if result != nil {
+		sort.Strings(names)
+		result.Name = strings.Join(names, ",")
+	}
+
+	limitRangeCache[namespace] = result
+
+	return result, nil
+}
+
+// maxQuantity returns bigger of value and resource in list.
+func maxQuantity(value string, list corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, ok := list[name]
+	if !ok {
+		return value
+	}
+
+	if current, err := resource.ParseQuantity(value); err == nil && current.Cmp(quantity) >= 0 {
+		return value
+	}
+
+	return quantity.String()
+}
+
+// minQuantity returns smaller of value and resource in list.
+func minQuantity(value string, list corev1.ResourceList, name corev1.ResourceName) string {
+	quantity, ok := list[name]
+	if !ok {
+		return value
+	}
+
+	if current, err := resource.ParseQuantity(value); err == nil && current.Cmp(quantity) <= 0 {
+		return value
+	}
+
+	return quantity.String()
+}
+
+// minRatio returns smaller of ratio and resource in list, 0 ratio is not set.
+func minRatio(value float64, list corev1.ResourceList, name corev1.ResourceName) float64 {
+	quantity, ok := list[name]
+	if !ok {
+		return value
+	}
+
+	if ratio := quantity.AsApproximateFloat64(); value == 0 || ratio < value {
+		return ratio
+	}
+
+	return value
+}
+
+// GetResourceQuotas returns ResourceQuotas of namespaces of pods.
+func GetResourceQuotas(pods []*types.PodResources) ([]types.ResourceQuota, error) {
+	result := make([]types.ResourceQuota, 0)
+
+	if !*config.Get().Quota {
+		return result, nil
+	}
+
+	namespaces := make(map[string]bool)
+
+	for _, pod := range pods {
+		if namespaces[pod.Namespace] {
+			continue
+		}
+
+		namespaces[pod.Namespace] = true
+
+		quotas, err := clientset.CoreV1().ResourceQuotas(pod.Namespace).List(context.Background(), metav1.ListOptions{})
+		if err != nil {
+			// tool can be used without permissions to read quotas
+			if apierrors.IsForbidden(err) {
+				log.WithError(err).Warn("resource quotas are not checked")
+
+				continue
+			}
+
+			return nil, errors.Wrap(err, "error list resource quotas")
+		}
+
+		for _, quota := range quotas.Items {
+			// scoped quotas count only part of pods, for example BestEffort or PriorityClass
+			if len(quota.Spec.Scopes) > 0 || quota.Spec.ScopeSelector != nil {
+				log.Infof("resource quota %s/%s with scopes is not checked", quota.Namespace, quota.Name)
+
+				continue
+			}
+
+			item := types.ResourceQuota{
+				Namespace: quota.Namespace,
+				Name:      quota.Name,
+				Hard:      quotaResources(quota.Status.Hard),
+				Used:      quotaResources(quota.Status.Used),
+			}
+
+			if len(item.Hard) > 0 {
+				result = append(result, item)
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// quotaResources returns cpu and memory resources of quota, cpu and memory are aliases of requests.
+func quotaResources(list corev1.ResourceList) map[string]float64 {
+	result := make(map[string]float64)
+
+	for name, quantity := range list {
+		switch name { //nolint:exhaustive
+		case corev1.ResourceCPU, corev1.ResourceRequestsCPU:
+			result[types.QuotaRequestsCPU] = quantity.AsApproximateFloat64()
+		case corev1.ResourceMemory, corev1.ResourceRequestsMemory:
+			result[types.QuotaRequestsMemory] = quantity.AsApproximateFloat64()
+		case corev1.ResourceLimitsCPU:
+			result[types.QuotaLimitsCPU] = quantity.AsApproximateFloat64()
+		case corev1.ResourceLimitsMemory:
+			result[types.QuotaLimitsMemory] = quantity.AsApproximateFloat64()
+		}
+	}
+
+	return result
+}
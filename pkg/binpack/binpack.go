@@ -0,0 +1,434 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package binpack
+
+import (
+	"math"
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Result of simulation for node pool.
+type Result struct {
+	NodePool string
+	// nodes in cluster now
+	Nodes int
+	// nodes needed for pods with current requests
+	CurrentNodes int
+	// nodes needed for pods with recommended requests
+	RecomendedNodes int
+	// pods that do not fit on empty node of any node pool
+	Unschedulable int
+}
+
+type resources struct {
+	cpu    float64
+	memory float64
+	pods   float64
+}
+
+func (r resources) add(other resources) resources {
+	return resources{cpu: r.cpu + other.cpu, memory: r.memory + other.memory, pods: r.pods + other.pods}
+}
+
+func (r resources) fits(capacity resources) bool {
+	return r.cpu <= capacity.cpu && r.memory <= capacity.memory && r.pods <= capacity.pods
+}
+
+type pod struct {
+	name         string
+	nodeName     string
+	nodePool     string
+	daemonSet    bool
+	current      resources
+	recomended   resources
+	nodeSelector map[string]string
+	tolerations  []corev1.Toleration
+}
+
+func (p *pod) requests(useRecomendations bool) resources {
+	if useRecomendations {
+		return p.recomended
+	}
+
+	return p.current
+}
+
+type pool struct {
+	name     string
+	nodes    int
+	capacity resources
+	labels   map[string]string
+	taints   []corev1.Taint
+	// resources of DaemonSet pods that are running on every node of pool
+	daemonSets           resources
+	recomendedDaemonSets resources
+}
+
+func (p *pool) allocatable(useRecomendations bool) resources {
+	daemonSets := p.daemonSets
+	if useRecomendations {
+		daemonSets = p.recomendedDaemonSets
+	}
+
+	return resources{
+		cpu:    p.capacity.cpu - daemonSets.cpu,
+		memory: p.capacity.memory - daemonSets.memory,
+		pods:   p.capacity.pods - daemonSets.pods,
+	}
+}
+
+// isSchedulable checks pod nodeSelector and tolerations, node affinity is not supported.
+func (p *pool) isSchedulable(item *pod) bool {
+	for key, value := range item.nodeSelector {
+		if p.labels[key] != value {
+			return false
+		}
+	}
+
+	for i := range p.taints {
+		taint := p.taints[i]
+
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+
+		tolerated := false
+
+		for j := range item.tolerations {
+			if item.tolerations[j].ToleratesTaint(&taint) {
+				tolerated = true
+
+				break
+			}
+		}
+
+		if !tolerated {
+			return false
+		}
+	}
+
+	return true
+}
+
+type node struct {
+	pool *pool
+	used resources
+}
+
+// Simulate packs pods on nodes of the same node pools with first-fit-decreasing algorithm,
+// with current requests and with recommended requests.
+func Simulate(nodeResources []*types.NodeResources, podResources []*types.PodResources) []*Result {
+	pools := getPools(nodeResources)
+	pods := getPods(podResources)
+
+	addDaemonSets(pools, nodeResources, pods)
+
+	currentNodes, currentUnschedulable := pack(pools, pods, false)
+	recomendedNodes, recomendedUnschedulable := pack(pools, pods, true)
+
+	result := make([]*Result, 0, len(pools))
+	poolNames := make(map[string]bool)
+
+	for _, pool := range pools {
+		poolNames[pool.name] = true
+
+		result = append(result, &Result{
+			NodePool:        pool.name,
+			Nodes:           pool.nodes,
+			CurrentNodes:    currentNodes[pool.name],
+			RecomendedNodes: recomendedNodes[pool.name],
+			Unschedulable:   max(currentUnschedulable[pool.name], recomendedUnschedulable[pool.name]),
+		})
+	}
+
+	// pending pods or pods on unschedulable nodes
+	for _, unschedulable := range []map[string]int{currentUnschedulable, recomendedUnschedulable} {
+		for name := range unschedulable {
+			if !poolNames[name] {
+				poolNames[name] = true
+
+				result = append(result, &Result{
+					NodePool:      name,
+					Unschedulable: max(currentUnschedulable[name], recomendedUnschedulable[name]),
+				})
+			}
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].NodePool < result[j].NodePool
+	})
+
+	return result
+}
+
+func getPools(nodes []*types.NodeResources) []*pool {
+	// nodes of caller are not reordered
+	nodeResources := append([]*types.NodeResources{}, nodes...)
+
+	sort.Slice(nodeResources, func(i, j int) bool {
+		return nodeResources[i].NodeName < nodeResources[j].NodeName
+	})
+
+	pools := make(map[string]*pool)
+	result := make([]*pool, 0)
+
+	for _, nodeResource := range nodeResources {
+		if nodeResource.Unschedulable {
+			continue
+		}
+
+		item, ok := pools[nodeResource.NodePool]
+		if !ok {
+			// first node is template of all new nodes in pool
+			item = &pool{
+				name: nodeResource.NodePool,
+				capacity: resources{
+					cpu:    utils.QuantityToFloat(nodeResource.AllocatableCPU),
+					memory: utils.QuantityToFloat(nodeResource.AllocatableMemory),
+					pods:   float64(nodeResource.AllocatablePods),
+				},
+				labels: make(map[string]string),
+				taints: nodeResource.Taints,
+			}
+
+			for key, value := range nodeResource.Labels {
+				item.labels[key] = value
+			}
+
+			pools[nodeResource.NodePool] = item
+			result = append(result, item)
+		}
+
+		// only labels that are the same on all nodes of pool can be used in nodeSelector
+		for key, value := range item.labels {
+			if nodeResource.Labels[key] != value {
+				delete(item.labels, key)
+			}
+		}
+
+		item.nodes++
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].name < result[j].name
+	})
+
+	return result
+}
+
+// getPods groups containers by pods, completed and evicted pods are ignored.
+func getPods(podResources []*types.PodResources) []*pod {
+	pods := make(map[string]*pod)
+	initContainers := make(map[string]resources)
+	initContainersRecomended := make(map[string]resources)
+	result := make([]*pod, 0)
+
+	for _, podResource := range podResources {
+		if podResource.Evicted || podResource.Phase == string(corev1.PodSucceeded) || podResource.Phase == string(corev1.PodFailed) { //nolint:lll
+			continue
+		}
+
+		name := podResource.GetPodNamespaceName()
+
+		item, ok := pods[name]
+		if !ok {
+			item = &pod{
+				name:         name,
+				nodeName:     podResource.NodeName,
+				nodePool:     podResource.NodePool,
+				daemonSet:    podResource.IsDaemonSet(),
+				current:      resources{pods: 1},
+				recomended:   resources{pods: 1},
+				nodeSelector: podResource.NodeSelector,
+				tolerations:  podResource.Tolerations,
+			}
+
+			pods[name] = item
+			result = append(result, item)
+		}
+
+		current, recomended := containerRequests(podResource)
+
+		// init containers are running before other containers, pod needs maximum of them
+		if podResource.InitContainer {
+			initContainers[name] = maxResources(initContainers[name], current)
+			initContainersRecomended[name] = maxResources(initContainersRecomended[name], recomended)
+
+			continue
+		}
+
+		item.current = item.current.add(current)
+		item.recomended = item.recomended.add(recomended)
+	}
+
+	for _, item := range result {
+		item.current = maxResources(item.current, initContainers[item.name])
+		item.recomended = maxResources(item.recomended, initContainersRecomended[item.name])
+	}
+
+	return result
+}
+
+func containerRequests(podResource *types.PodResources) (resources, resources) {
+	current := resources{
+		cpu:    utils.QuantityToFloat(podResource.CPURequest),
+		memory: utils.QuantityToFloat(podResource.MemoryRequest),
+	}
+
+	recomended := current
+
+	if recomendation := podResource.GetRecomendation(); recomendation != nil {
+		if len(recomendation.CPURequest) > 0 {
+			recomended.cpu = utils.QuantityToFloat(recomendation.CPURequest)
+		}
+
+		if len(recomendation.MemoryRequest) > 0 {
+			recomended.memory = utils.QuantityToFloat(recomendation.MemoryRequest)
+		}
+	}
+
+	return current, recomended
+}
+
+func maxResources(a, b resources) resources {
+	return resources{
+		cpu:    math.Max(a.cpu, b.cpu),
+		memory: math.Max(a.memory, b.memory),
+		pods:   math.Max(a.pods, b.pods),
+	}
+}
+
+// addDaemonSets reserves on every node of pool maximum of DaemonSet pods requests on nodes of this pool.
+func addDaemonSets(pools []*pool, nodeResources []*types.NodeResources, pods []*pod) {
+	nodePools := make(map[string]*pool)
+
+	for _, nodeResource := range nodeResources {
+		for _, item := range pools {
+			if item.name == nodeResource.NodePool {
+				nodePools[nodeResource.NodeName] = item
+			}
+		}
+	}
+
+	daemonSets := make(map[string]resources)
+	recomendedDaemonSets := make(map[string]resources)
+
+	for _, item := range pods {
+		if !item.daemonSet {
+			continue
+		}
+
+		daemonSets[item.nodeName] = daemonSets[item.nodeName].add(item.current)
+		recomendedDaemonSets[item.nodeName] = recomendedDaemonSets[item.nodeName].add(item.recomended)
+	}
+
+	for nodeName, nodePool := range nodePools {
+		nodePool.daemonSets = maxResources(nodePool.daemonSets, daemonSets[nodeName])
+		nodePool.recomendedDaemonSets = maxResources(nodePool.recomendedDaemonSets, recomendedDaemonSets[nodeName])
+	}
+}
+
+// pack returns number of nodes and unschedulable pods by node pool,
+// pods are placed on first node that fits starting from the largest pods,
+// pod prefers node pool where it is running now.
+func pack(pools []*pool, pods []*pod, useRecomendations bool) (map[string]int, map[string]int) { //nolint:cyclop
+	workloads := make([]*pod, 0, len(pods))
+
+	for _, item := range pods {
+		if !item.daemonSet {
+			workloads = append(workloads, item)
+		}
+	}
+
+	sort.SliceStable(workloads, func(i, j int) bool {
+		left := workloads[i].requests(useRecomendations)
+		right := workloads[j].requests(useRecomendations)
+
+		if left.memory != right.memory {
+			return left.memory > right.memory
+		}
+
+		return left.cpu > right.cpu
+	})
+
+	nodes := make([]*node, 0)
+	nodesByPool := make(map[string]int)
+	unschedulable := make(map[string]int)
+
+	for _, item := range workloads {
+		requests := item.requests(useRecomendations)
+		candidates := candidatePools(pools, item)
+
+		placed := false
+
+		for _, candidate := range candidates {
+			for _, n := range nodes {
+				if n.pool == candidate && n.used.add(requests).fits(candidate.allocatable(useRecomendations)) {
+					n.used = n.used.add(requests)
+					placed = true
+
+					break
+				}
+			}
+
+			if placed {
+				break
+			}
+		}
+
+		if placed {
+			continue
+		}
+
+		// open new node in first node pool where pod fits
+		for _, candidate := range candidates {
+			if requests.fits(candidate.allocatable(useRecomendations)) {
+				nodes = append(nodes, &node{pool: candidate, used: requests})
+				nodesByPool[candidate.name]++
+				placed = true
+
+				break
+			}
+		}
+
+		if !placed {
+			unschedulable[item.nodePool]++
+		}
+	}
+
+	return nodesByPool, unschedulable
+}
+
+// candidatePools returns node pools where pod can be scheduled, current node pool of pod is first.
+func candidatePools(pools []*pool, item *pod) []*pool {
+	result := make([]*pool, 0, len(pools))
+
+	for _, candidate := range pools {
+		if candidate.name == item.nodePool && candidate.isSchedulable(item) {
+			result = append(result, candidate)
+		}
+	}
+
+	for _, candidate := range pools {
+		if candidate.name != item.nodePool && candidate.isSchedulable(item) {
+			result = append(result, candidate)
+		}
+	}
+
+	return result
+}
@@ -0,0 +1,115 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package binpack_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/binpack"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func newNode(name, pool string, taints []corev1.Taint) *types.NodeResources {
+	return &types.NodeResources{
+		NodeName:          name,
+		NodePool:          pool,
+		AllocatableCPU:    "4",
+		AllocatableMemory: "8Gi",
+		AllocatablePods:   110,
+		Labels:            map[string]string{"pool": pool, "kubernetes.io/hostname": name},
+		Taints:            taints,
+	}
+}
+
+func newPod(name, nodeName, pool, cpu, memory, recomendedCPU, recomendedMemory string) *types.PodResources {
+	pod := types.PodResources{
+		PodName:       name,
+		Namespace:     "test",
+		NodeName:      nodeName,
+		NodePool:      pool,
+		CPURequest:    cpu,
+		MemoryRequest: memory,
+	}
+
+	pod.SetRecomendation(&types.Recomendations{CPURequest: recomendedCPU, MemoryRequest: recomendedMemory})
+
+	return &pod
+}
+
+func TestSimulate(t *testing.T) {
+	t.Parallel()
+
+	gpuTaint := []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
+
+	nodes := []*types.NodeResources{
+		newNode("a-1", "a", nil),
+		newNode("a-2", "a", nil),
+		newNode("a-3", "a", nil),
+		newNode("b-1", "b", gpuTaint),
+	}
+
+	pods := make([]*types.PodResources, 0)
+
+	// 1 core and 2Gi of every node is used by DaemonSet, recommended 500m and 1Gi
+	for _, node := range nodes {
+		daemonSet := newPod("ds-"+node.NodeName, node.NodeName, node.NodePool, "1", "2Gi", "500m", "1Gi")
+		daemonSet.OwnerKind = "DaemonSet"
+		daemonSet.Tolerations = []corev1.Toleration{{Operator: corev1.TolerationOpExists}}
+
+		pods = append(pods, daemonSet)
+	}
+
+	for i := 0; i < 6; i++ {
+		pods = append(pods, newPod(fmt.Sprintf("app-%d", i), "a-1", "a", "1", "2Gi", "500m", "1Gi"))
+	}
+
+	gpu := newPod("gpu", "b-1", "b", "2", "4Gi", "2", "4Gi")
+	gpu.NodeSelector = map[string]string{"pool": "b"}
+	gpu.Tolerations = []corev1.Toleration{{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "gpu"}}
+
+	pods = append(pods, gpu, newPod("huge", "", "", "16", "1Gi", "16", "1Gi"))
+
+	want := map[string]binpack.Result{
+		// 3 pods fit on node with current requests, 7 pods with recommended requests
+		"a": {NodePool: "a", Nodes: 3, CurrentNodes: 2, RecomendedNodes: 1},
+		"b": {NodePool: "b", Nodes: 1, CurrentNodes: 1, RecomendedNodes: 1},
+		"":  {NodePool: "", Unschedulable: 1},
+	}
+
+	results := binpack.Simulate(nodes, pods)
+
+	if len(results) != len(want) {
+		t.Fatalf("want %d results, got %d", len(want), len(results))
+	}
+
+	for _, result := range results {
+		if *result != want[result.NodePool] {
+			t.Fatalf("pool %q: want %+v, got %+v", result.NodePool, want[result.NodePool], *result)
+		}
+	}
+}
+
+func TestSimulateKeepsOrder(t *testing.T) {
+	t.Parallel()
+
+	nodes := []*types.NodeResources{newNode("b-1", "b", nil), newNode("a-1", "a", nil)}
+	pods := []*types.PodResources{newPod("app", "b-1", "b", "1", "2Gi", "500m", "1Gi")}
+
+	binpack.Simulate(nodes, pods)
+
+	if nodes[0].NodeName != "b-1" || nodes[1].NodeName != "a-1" {
+		t.Fatalf("want nodes of caller in order b-1,a-1, got %s,%s", nodes[0].NodeName, nodes[1].NodeName)
+	}
+}
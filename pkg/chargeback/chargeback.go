@@ -0,0 +1,116 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package chargeback
+
+import (
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// name of group for containers without label.
+const UnownedGroup = "unowned"
+
+const percents = 100
+
+// Group of containers with the same label value, cpu in cores, memory in bytes.
+type Group struct {
+	Name          string
+	Containers    int
+	CPURequest    float64
+	CPUUsage      float64
+	MemoryRequest float64
+	MemoryUsage   float64
+	// cost of requested resources per month, calculated only if cost model is configured
+	MonthlyCost float64
+}
+
+// CPUEfficiency returns usage of requested cpu in percents, -1 if cpu is not requested.
+func (g *Group) CPUEfficiency() float64 {
+	return efficiency(g.CPUUsage, g.CPURequest)
+}
+
+// MemoryEfficiency returns usage of requested memory in percents, -1 if memory is not requested.
+func (g *Group) MemoryEfficiency() float64 {
+	return efficiency(g.MemoryUsage, g.MemoryRequest)
+}
+
+func efficiency(usage, request float64) float64 {
+	if request == 0 {
+		return -1
+	}
+
+	return usage / request * percents
+}
+
+// GetOwner returns value of label from pod labels or from namespace labels.
+func GetOwner(pod *types.PodResources, label string) string {
+	if owner := pod.Labels[label]; len(owner) > 0 {
+		return owner
+	}
+
+	if owner := pod.NamespaceLabels[label]; len(owner) > 0 {
+		return owner
+	}
+
+	return UnownedGroup
+}
+
+// Get returns groups of containers by label sorted by name, unowned group is always last.
+func Get(pods []*types.PodResources, label string) []*Group {
+	groups := make(map[string]*Group)
+
+	for _, pod := range pods {
+		owner := GetOwner(pod, label)
+
+		group, ok := groups[owner]
+		if !ok {
+			group = &Group{Name: owner}
+			groups[owner] = group
+		}
+
+		cpuRequest := utils.QuantityToFloat(pod.CPURequest)
+		memoryRequest := utils.QuantityToFloat(pod.MemoryRequest)
+
+		group.Containers++
+		group.CPURequest += cpuRequest
+		group.MemoryRequest += memoryRequest
+
+		if pod.Usage != nil {
+			group.CPUUsage += pod.Usage.CPU
+			group.MemoryUsage += pod.Usage.Memory
+		}
+
+		if cost := config.Get().Cost; cost != nil {
+			group.MonthlyCost += cost.GetMonthlyCost(pod.NodePool, cpuRequest, memoryRequest)
+		}
+	}
+
+	result := make([]*Group, 0, len(groups))
+
+	for _, group := range groups {
+		result = append(result, group)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Name == UnownedGroup || result[j].Name == UnownedGroup {
+			return result[j].Name == UnownedGroup && result[i].Name != UnownedGroup
+		}
+
+		return result[i].Name < result[j].Name
+	})
+
+	return result
+}
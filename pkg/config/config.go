@@ -15,12 +15,54 @@ package config
 import (
 	"flag"
 	"os"
+	"sort"
+	"strings"
+	"time"
 
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/filter"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/templates"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
 	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
 	"gopkg.in/yaml.v3"
 )
 
+// Price of resources per hour.
+type ResourcesCost struct {
+	CPUHour       float64 `yaml:"cpuHour"`
+	MemoryGiBHour float64 `yaml:"memoryGiBHour"`
+}
+
+// Cost model to calculate savings, prices can be overridden for node pools.
+type CostConfig struct {
+	ResourcesCost `yaml:",inline"`
+	NodePools     map[string]ResourcesCost `yaml:"nodePools"`
+	// node label of node pool, used when -nodePoolLabel is not set
+	NodePoolLabel string `yaml:"nodePoolLabel"`
+}
+
+// GetNodePoolCost returns price for node pool or default price.
+func (c *CostConfig) GetNodePoolCost(nodePool string) ResourcesCost {
+	if cost, ok := c.NodePools[nodePool]; ok {
+		return cost
+	}
+
+	return c.ResourcesCost
+}
+
+const (
+	// average number of hours in month.
+	HoursInMonth = 730
+	bytesInGiB   = 1 << 30
+)
+
+// GetMonthlyCost returns price of cpu cores and memory bytes per month on node pool.
+func (c *CostConfig) GetMonthlyCost(nodePool string, cpu, memory float64) float64 {
+	price := c.GetNodePoolCost(nodePool)
+
+	return (cpu*price.CPUHour + memory/bytesInGiB*price.MemoryGiBHour) * HoursInMonth
+}
+
 type AppConfig struct {
 	ConfigFile           *string
 	KubeConfigFile       *string
@@ -43,6 +85,43 @@ type AppConfig struct {
 	Strategy             *string
 	GroupBy              *string
 	InitContainers       *bool
+	SortBy               *string
+	Top                  *int
+	ShowSummary          *bool
+	Cost                 *CostConfig
+	Report               *string
+	ChargebackLabel      *string
+	NodePoolLabel        *string
+	NodesOvercommit      *float64
+	ThrottlingThreshold  *float64
+	ThrottlingRemove     *float64
+	OOMRiskHorizon       *string
+	ShowRestarts         *bool
+	MinConfidence        *string
+	Force                *bool
+	Explain              *bool
+	CustomStrategies     map[string]types.Strategy `yaml:"customStrategies"`
+	Seasonality          *bool
+	SeasonalityTimezone  *string
+	SeasonalityRatio     *float64
+	SeasonalityRequests  *bool
+	StartupIgnore        *string
+	Exclude              *string
+	ExcludeWindows       []types.TimeWindow `yaml:"excludeWindows"`
+	HPA                  *bool
+	HPAKeepReplicas      *bool
+	ReplicasHeadroom     *float64
+	ReplicasMin          *int
+	ImageSplit           *bool
+	ImageRegression      *float64
+	LimitRange           *bool
+	LimitRangeClamp      *bool
+	Quota                *bool
+	PoliciesHeadroom     *float64
+	LintRules            *string
+	Output               *string
+	Columns              *string
+	TUIPatchFile         *string
 }
 
 func (c *AppConfig) String() string {
@@ -60,7 +139,7 @@ var appConfig = &AppConfig{
 	Namespace:            flag.String("namespace", "", "filter by namespace"),
 	LogLevel:             flag.String("logLevel", "INFO", "log level"),
 	KubeConfigFile:       flag.String("kubeconfig", os.Getenv("KUBECONFIG"), "kubeconfig path"),
-	Filter:               flag.String("filter", "", "golang filter expression"),
+	Filter:               flag.String("filter", "", "filter expression, for example .NodeName==node1 && .MemoryRequest>1Gi"), //nolint:lll
 	PodLabelSelector:     flag.String("podLabelSelector", "", "pod label selector"),
 	InitContainers:       flag.Bool("initContainers", true, "show init containers"),
 	ShowQoS:              flag.Bool("ShowQoS", false, "show QoS"),
@@ -75,8 +154,42 @@ var appConfig = &AppConfig{
 	PrometheusGroupValue: flag.String("prometheus.group.value", "", "prometheus shared group value"),
 	PrometheusRetention:  flag.String("prometheus.retention", "7d", "period of metrics to process"),
 	ShowDebugJSON:        flag.Bool("ShowDebugJSON", false, "show debug json"),
-	Strategy:             flag.String("strategy", "conservative", "strategy to calculate container limits"),
+	Strategy:             flag.String("strategy", "conservative", "comma separated strategies to calculate container limits"), //nolint:lll
 	GroupBy:              flag.String("groupby", "podtemplate", "collect type"),
+	SortBy:               flag.String("sort-by", "", "sort results by fields, for example MemoryRequestWaste:desc,PodName"), //nolint:lll
+	Top:                  flag.Int("top", 0, "show only first N results after sorting"),
+	ShowSummary:          flag.Bool("ShowSummary", false, "show summary of requested and recommended resources"),
+	Report:               flag.String("report", "pods", "report: pods, chargeback, nodes, binpack, replicas, policies"),
+	ChargebackLabel:      flag.String("chargeback.label", "team", "pod or namespace label to group chargeback report"),
+	NodePoolLabel:        flag.String("nodePoolLabel", "node.kubernetes.io/instance-type", "node label with node pool name"),                    //nolint:lll
+	NodesOvercommit:      flag.Float64("nodes.overcommit", 2, "node is overcommitted when sum of limits is bigger than allocatable in N times"), //nolint:lll
+	ThrottlingThreshold:  flag.Float64("throttling.threshold", 10, "percents of throttled cpu periods to raise cpu limit"),
+	ThrottlingRemove:     flag.Float64("throttling.removeLimit", 50, "percents of throttled cpu periods to remove cpu limit"),                //nolint:lll
+	OOMRiskHorizon:       flag.String("oomRisk.horizon", "", "flag containers that will reach memory limit in this period, for example 72h"), //nolint:lll
+	ShowRestarts:         flag.Bool("ShowRestarts", false, "show restarts and OOMKilled history"),
+	MinConfidence:        flag.String("minConfidence", "medium", "minimal confidence of recomendation to export: low, medium, high"), //nolint:lll
+	Force:                flag.Bool("force", false, "export recomendations with confidence lower than minConfidence"),
+	Explain:              flag.Bool("explain", false, "show how every recomendation was calculated"),
+	Seasonality:          flag.Bool("seasonality", false, "analyze usage by hour of day and weekday"),
+	SeasonalityTimezone:  flag.String("seasonality.timezone", "UTC", "timezone of hours in seasonality analysis"),
+	SeasonalityRatio:     flag.Float64("seasonality.ratio", 2, "peak to off-peak usage ratio of scheduled scaling candidates"), //nolint:lll
+	SeasonalityRequests:  flag.Bool("seasonality.peakRequests", false, "recommend requests sized for peak window"),
+	StartupIgnore:        flag.String("startup.ignore", "", "ignore usage in first period of container life, for example 5m"),       //nolint:lll
+	Exclude:              flag.String("exclude", "", "comma separated time windows to exclude from analysis, start/end in RFC3339"), //nolint:lll
+	HPA:                  flag.Bool("hpa", true, "detect horizontal pod autoscalers of workloads"),
+	HPAKeepReplicas:      flag.Bool("hpa.keepReplicas", true, "keep requests of resources used by horizontal pod autoscaler"), //nolint:lll
+	ReplicasHeadroom:     flag.Float64("replicas.headroom", 30, "percents of cpu headroom over peak usage of all replicas"),   //nolint:lll
+	ReplicasMin:          flag.Int("replicas.min", 2, "minimal replicas in replicas report"),
+	ImageSplit:           flag.Bool("image.split", false, "recommend for running image, compare images"),
+	ImageRegression:      flag.Float64("image.regression", 20, "usage change percents between images"),
+	LimitRange:           flag.Bool("limitRange", true, "check recomendations with LimitRanges of namespaces"),
+	LimitRangeClamp:      flag.Bool("limitRange.clamp", false, "clamp recomendations to LimitRange min and max"),
+	Quota:                flag.Bool("quota", true, "show ResourceQuota headroom of namespaces"),
+	PoliciesHeadroom:     flag.Float64("policies.headroom", 20, "growth headroom percents of proposed quotas"),
+	LintRules:            flag.String("lint.rules", "lint.yaml", "rules file of lint command"),
+	Output:               flag.String("output", "text", "output: text, markdown, html, sarif, junit, template=path"),
+	Columns:              flag.String("columns", "", "columns of table, for example PodName,NodeName,MemoryRequest"),
+	TUIPatchFile:         flag.String("tui.patchFile", "patch.sh", "file of kubectl commands with marked rows in tui"),
 }
 
 func Load() error {
@@ -94,11 +207,27 @@ func Load() error {
 		return errors.Wrap(err, "error unmarshal config")
 	}
 
+	if appConfig.Cost != nil && len(appConfig.Cost.NodePoolLabel) > 0 && !isFlagSet("nodePoolLabel") {
+		*appConfig.NodePoolLabel = appConfig.Cost.NodePoolLabel
+	}
+
 	return nil
 }
 
+func isFlagSet(name string) bool {
+	result := false
+
+	flag.Visit(func(f *flag.Flag) {
+		if f.Name == name {
+			result = true
+		}
+	})
+
+	return result
+}
+
 func Check() error {
-	_, err := types.ParseStrategyType(*appConfig.Strategy)
+	_, err := types.ParseStrategies(*appConfig.Strategy, appConfig.CustomStrategies)
 	if err != nil {
 		return errors.Wrap(err, "error parse limits strategy")
 	}
@@ -108,9 +237,138 @@ func Check() error {
 		return errors.Wrap(err, "error parse collector type")
 	}
 
+	reportType, err := types.ParseReportType(*appConfig.Report)
+	if err != nil {
+		return errors.Wrap(err, "error parse report type")
+	}
+
+	outputFormat, err := types.ParseOutputFormat(*appConfig.Output)
+	if err != nil {
+		return errors.Wrap(err, "error parse output format")
+	}
+
+	if outputFormat != types.OutputFormatText && reportType != types.ReportTypePods {
+		return errors.Errorf("output %s can be used only with pods report", outputFormat)
+	}
+
+	if outputFormat == types.OutputFormatTemplate {
+		if _, err := templates.Parse(*appConfig.Output); err != nil {
+			return errors.Wrap(err, "error parse output template")
+		}
+	}
+
+	if reportType == types.ReportTypeReplicas && len(*appConfig.PrometheusURL) == 0 {
+		return errors.New("replicas report requires -prometheus.url")
+	}
+
+	if reportType == types.ReportTypeNodes || reportType == types.ReportTypeBinpack {
+		if flags := getNarrowingFlags(); len(flags) > 0 {
+			return errors.Errorf("%s report needs all pods of cluster, %s can not be used", reportType, strings.Join(flags, ", ")) //nolint:lll
+		}
+	}
+
+	if len(*appConfig.OOMRiskHorizon) > 0 {
+		if _, err := model.ParseDuration(*appConfig.OOMRiskHorizon); err != nil {
+			return errors.Wrap(err, "error parse oomRisk.horizon")
+		}
+	}
+
+	if _, err := time.LoadLocation(*appConfig.SeasonalityTimezone); err != nil {
+		return errors.Wrap(err, "error parse seasonality.timezone")
+	}
+
+	if len(*appConfig.StartupIgnore) > 0 {
+		if _, err := model.ParseDuration(*appConfig.StartupIgnore); err != nil {
+			return errors.Wrap(err, "error parse startup.ignore")
+		}
+	}
+
+	if _, err := GetExcludeWindows(); err != nil {
+		return errors.Wrap(err, "error parse exclude")
+	}
+
+	if _, err := types.ParseConfidence(*appConfig.MinConfidence); err != nil {
+		return errors.Wrap(err, "error parse minConfidence")
+	}
+
+	if err := checkFilter(); err != nil {
+		return errors.Wrap(err, "error parse filter")
+	}
+
+	if _, err := types.ParseSortBy(*appConfig.SortBy); err != nil {
+		return errors.Wrap(err, "error parse sort-by")
+	}
+
+	if _, err := types.ParseColumns(*appConfig.Columns); err != nil {
+		return errors.Wrap(err, "error parse columns")
+	}
+
 	return nil
 }
 
+// getNarrowingFlags returns flags that scan only part of pods.
+func getNarrowingFlags() []string {
+	flags := map[string]bool{
+		"-namespace":        len(*appConfig.Namespace) > 0,
+		"-podLabelSelector": len(*appConfig.PodLabelSelector) > 0,
+		"-filter":           len(*appConfig.Filter) > 0,
+		"-NoCPURequest":     *appConfig.NoCPURequest,
+		"-NoMemoryRequest":  *appConfig.NoMemoryRequest,
+		"-OOMKilled":        *appConfig.OOMKilled,
+	}
+
+	result := make([]string, 0)
+
+	for name, enabled := range flags {
+		if enabled {
+			result = append(result, name)
+		}
+	}
+
+	sort.Strings(result)
+
+	return result
+}
+
+func checkFilter() error {
+	if len(*appConfig.Filter) == 0 {
+		return nil
+	}
+
+	expression, err := filter.Parse(*appConfig.Filter, types.GetFieldNames())
+	if err != nil {
+		return errors.Wrap(err, *appConfig.Filter)
+	}
+
+	if len(*appConfig.PrometheusURL) > 0 {
+		return nil
+	}
+
+	for _, name := range expression.Fields() {
+		if field, _ := types.GetField(name); field != nil && field.Recomendation {
+			return errors.Errorf("field %s requires -prometheus.url", name)
+		}
+	}
+
+	return nil
+}
+
+// GetExcludeWindows returns time windows from flag and config file.
+func GetExcludeWindows() ([]types.TimeWindow, error) {
+	windows, err := types.ParseTimeWindows(*appConfig.Exclude)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	for _, window := range appConfig.ExcludeWindows {
+		if err := window.Check(); err != nil {
+			return nil, err //nolint:wrapcheck
+		}
+	}
+
+	return append(windows, appConfig.ExcludeWindows...), nil
+}
+
 func Get() *AppConfig {
 	return appConfig
 }
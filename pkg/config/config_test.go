@@ -41,4 +41,24 @@ func TestConfig(t *testing.T) {
 	if *config.Get().ShowQoS != true {
 		t.Fatalf("expected ShowQoS to be true, got %v", *config.Get().ShowQoS)
 	}
+
+	if cost := config.Get().Cost.GetNodePoolCost("spot"); cost.CPUHour != 0.01 {
+		t.Fatalf("expected spot cpu price to be 0.01, got %v", cost.CPUHour)
+	}
+
+	if label := *config.Get().NodePoolLabel; label != "cloud.google.com/gke-nodepool" {
+		t.Fatalf("expected node pool label from cost model, got %s", label)
+	}
+
+	if strategy := config.Get().CustomStrategies["p95"]; strategy.LimitQuantile != 0.95 {
+		t.Fatalf("expected p95 strategy quantile to be 0.95, got %v", strategy.LimitQuantile)
+	}
+
+	if windows, err := config.GetExcludeWindows(); err != nil || len(windows) != 1 || windows[0].Reason != "incident" {
+		t.Fatalf("expected one exclude window, got %v %v", windows, err)
+	}
+
+	if cost := config.Get().Cost.GetNodePoolCost("unknown"); cost.MemoryGiBHour != 0.004 {
+		t.Fatalf("expected default memory price to be 0.004, got %v", cost.MemoryGiBHour)
+	}
 }
@@ -0,0 +1,404 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package filter
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Error with position in filter expression.
+type Error struct {
+	Column  int
+	Message string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("column %d: %s", e.Column, e.Message)
+}
+
+func newError(column int, format string, args ...interface{}) *Error {
+	return &Error{Column: column, Message: fmt.Sprintf(format, args...)}
+}
+
+// Resolver returns value of field by name.
+type Resolver func(field string) (string, error)
+
+// Expression is parsed filter expression.
+//
+// Syntax:
+//
+//	.Field == value, .Field != value        equality, numbers and quantities are compared by value
+//	.Field =~ regexp, .Field !~ regexp      regular expression match of full value
+//	.Field > 1Gi, <, >=, <=                 numeric and quantity comparisons
+//	.Field                                  true if field value is "true"
+//	a && b, a || b, !a, (a)                 boolean logic, comma is alias of ||
+//
+// Values can be unquoted words (node-1, 500m, 1Gi) or quoted strings ("a,b").
+type Expression struct {
+	text   string
+	root   node
+	fields []string
+}
+
+// Parse filter expression, if knownFields is not nil all used fields must be in this list.
+func Parse(text string, knownFields []string) (*Expression, error) {
+	tokens, err := lex(text)
+	if err != nil {
+		return nil, err
+	}
+
+	p := parser{tokens: tokens, knownFields: knownFields}
+
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+
+	if t := p.current(); t.typ != tokenEOF {
+		return nil, newError(t.column, "unexpected %s", t)
+	}
+
+	return &Expression{text: text, root: root, fields: p.fields}, nil
+}
+
+func (e *Expression) String() string {
+	return e.text
+}
+
+// Fields returns all fields that are used in expression.
+func (e *Expression) Fields() []string {
+	return e.fields
+}
+
+// Match evaluates expression with field values from resolver.
+func (e *Expression) Match(resolver Resolver) (bool, error) {
+	return e.root.eval(resolver)
+}
+
+type node interface {
+	eval(resolver Resolver) (bool, error)
+}
+
+type orNode struct {
+	left, right node
+}
+
+func (n *orNode) eval(resolver Resolver) (bool, error) {
+	left, err := n.left.eval(resolver)
+	if err != nil || left {
+		return left, err
+	}
+
+	return n.right.eval(resolver)
+}
+
+type andNode struct {
+	left, right node
+}
+
+func (n *andNode) eval(resolver Resolver) (bool, error) {
+	left, err := n.left.eval(resolver)
+	if err != nil || !left {
+		return false, err
+	}
+
+	return n.right.eval(resolver)
+}
+
+type notNode struct {
+	expr node
+}
+
+func (n *notNode) eval(resolver Resolver) (bool, error) {
+	result, err := n.expr.eval(resolver)
+
+	return !result, err
+}
+
+type operand struct {
+	field string
+	value string
+}
+
+func (o operand) get(resolver Resolver) (string, error) {
+	if len(o.field) == 0 {
+		return o.value, nil
+	}
+
+	return resolver(o.field)
+}
+
+// boolNode is field without comparison, it is true when field value is "true".
+type boolNode struct {
+	operand operand
+}
+
+func (n *boolNode) eval(resolver Resolver) (bool, error) {
+	value, err := n.operand.get(resolver)
+	if err != nil {
+		return false, err
+	}
+
+	return value == "true", nil
+}
+
+type compareNode struct {
+	operator    string
+	column      int
+	left, right operand
+	regexp      *regexp.Regexp
+}
+
+func (n *compareNode) eval(resolver Resolver) (bool, error) { //nolint:cyclop
+	left, err := n.left.get(resolver)
+	if err != nil {
+		return false, err
+	}
+
+	if n.regexp != nil {
+		return n.regexp.MatchString(left) == (n.operator == "=~"), nil
+	}
+
+	right, err := n.right.get(resolver)
+	if err != nil {
+		return false, err
+	}
+
+	switch n.operator {
+	case "==":
+		return equal(left, right), nil
+	case "!=":
+		return !equal(left, right), nil
+	}
+
+	// values that are not calculated can not be compared
+	if len(left) == 0 || len(right) == 0 {
+		return false, nil
+	}
+
+	leftQuantity, leftErr := resource.ParseQuantity(left)
+	rightQuantity, rightErr := resource.ParseQuantity(right)
+
+	if leftErr != nil || rightErr != nil {
+		return false, newError(n.column, "can not compare %q %s %q, values must be numbers or quantities", left, n.operator, right) //nolint:lll
+	}
+
+	cmp := leftQuantity.Cmp(rightQuantity)
+
+	switch n.operator {
+	case "<":
+		return cmp < 0, nil
+	case "<=":
+		return cmp <= 0, nil
+	case ">":
+		return cmp > 0, nil
+	default:
+		return cmp >= 0, nil
+	}
+}
+
+// equal compares values as strings, numbers and quantities are compared by value (1Gi == 1024Mi).
+func equal(left, right string) bool {
+	if left == right {
+		return true
+	}
+
+	leftQuantity, err := resource.ParseQuantity(left)
+	if err != nil {
+		return false
+	}
+
+	rightQuantity, err := resource.ParseQuantity(right)
+	if err != nil {
+		return false
+	}
+
+	return leftQuantity.Cmp(rightQuantity) == 0
+}
+
+type parser struct {
+	tokens      []token
+	pos         int
+	knownFields []string
+	fields      []string
+}
+
+func (p *parser) current() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) advance() token {
+	t := p.tokens[p.pos]
+
+	if t.typ != tokenEOF {
+		p.pos++
+	}
+
+	return t
+}
+
+func (p *parser) parseOr() (node, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.current().typ == tokenOr {
+		p.advance()
+
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &orNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseAnd() (node, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+
+	for p.current().typ == tokenAnd {
+		p.advance()
+
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		left = &andNode{left: left, right: right}
+	}
+
+	return left, nil
+}
+
+func (p *parser) parseUnary() (node, error) {
+	switch t := p.current(); t.typ { //nolint:exhaustive
+	case tokenNot:
+		p.advance()
+
+		expr, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+
+		return &notNode{expr: expr}, nil
+	case tokenLeftParen:
+		p.advance()
+
+		expr, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+
+		if closing := p.current(); closing.typ != tokenRightParen {
+			return nil, newError(closing.column, "expected \")\" to close \"(\" at column %d, got %s", t.column, closing)
+		}
+
+		p.advance()
+
+		return expr, nil
+	default:
+		return p.parseComparison()
+	}
+}
+
+func (p *parser) parseComparison() (node, error) {
+	leftToken := p.current()
+
+	left, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	operator := p.current()
+
+	if operator.typ != tokenCompare {
+		if len(left.field) == 0 {
+			return nil, newError(operator.column, "expected comparison operator after %q, got %s", left.value, operator)
+		}
+
+		return &boolNode{operand: left}, nil
+	}
+
+	p.advance()
+
+	rightToken := p.current()
+
+	right, err := p.parseOperand()
+	if err != nil {
+		return nil, err
+	}
+
+	if len(left.field) == 0 && len(right.field) == 0 {
+		return nil, newError(leftToken.column, "comparison of two values %s and %s, field names must start with \".\"", leftToken, rightToken) //nolint:lll
+	}
+
+	result := compareNode{
+		operator: operator.value,
+		column:   operator.column,
+		left:     left,
+		right:    right,
+	}
+
+	if operator.value == "=~" || operator.value == "!~" {
+		if len(right.field) > 0 {
+			return nil, newError(rightToken.column, "regular expression must be a value, got field %s", rightToken)
+		}
+
+		// regular expressions are fully anchored like in PromQL
+		result.regexp, err = regexp.Compile("^(?:" + right.value + ")$")
+		if err != nil {
+			return nil, newError(rightToken.column, "invalid regular expression: %s", err.Error())
+		}
+	}
+
+	return &result, nil
+}
+
+func (p *parser) parseOperand() (operand, error) {
+	t := p.current()
+
+	switch t.typ { //nolint:exhaustive
+	case tokenField:
+		field := strings.TrimPrefix(t.value, ".")
+
+		if p.knownFields != nil && !slices.Contains(p.knownFields, field) {
+			return operand{}, newError(t.column, "unknown field %s, known fields: %s", t, strings.Join(p.knownFields, ", "))
+		}
+
+		if !slices.Contains(p.fields, field) {
+			p.fields = append(p.fields, field)
+		}
+
+		p.advance()
+
+		return operand{field: field}, nil
+	case tokenString, tokenWord:
+		p.advance()
+
+		return operand{value: t.value}, nil
+	default:
+		return operand{}, newError(t.column, "expected field or value, got %s", t)
+	}
+}
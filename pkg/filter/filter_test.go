@@ -0,0 +1,119 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package filter_test
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/filter"
+)
+
+func TestFilter(t *testing.T) {
+	t.Parallel()
+
+	values := map[string]string{
+		"NodeName":      "node-1",
+		"Namespace":     "prod",
+		"PodName":       "app,with,commas",
+		"MemoryRequest": "1Gi",
+		"CPURequest":    "500m",
+		"OOMKilled":     "true",
+		"Evicted":       "false",
+		"Empty":         "",
+	}
+
+	resolver := func(field string) (string, error) {
+		return values[field], nil
+	}
+
+	tests := map[string]bool{
+		".NodeName==node-1":                                       true,
+		".NodeName==node-2,.Namespace==prod":                      true,
+		".NodeName != node-1":                                     false,
+		`.PodName == "app,with,commas"`:                           true,
+		`.PodName == 'app,with'`:                                  false,
+		".MemoryRequest > 1Gi":                                    false,
+		".MemoryRequest >= 1Gi":                                   true,
+		".MemoryRequest == 1024Mi":                                true,
+		".MemoryRequest < 2Gi && .CPURequest > 0.4":               true,
+		".CPURequest <= 100m || .Namespace =~ pr.*":               true,
+		".Namespace =~ pr":                                        false,
+		`.Namespace !~ "dev|test"`:                                true,
+		".OOMKilled":                                              true,
+		"!.Evicted && .OOMKilled":                                 true,
+		"!(.Namespace == prod || .NodeName == node-1)":            false,
+		"(.Namespace == prod || .Namespace == dev) && .OOMKilled": true,
+		".Empty > 1Gi":                                            false,
+		".Empty == ''":                                            true,
+	}
+
+	for expression, want := range tests {
+		e, err := filter.Parse(expression, nil)
+		if err != nil {
+			t.Fatalf("%s: %s", expression, err)
+		}
+
+		got, err := e.Match(resolver)
+		if err != nil {
+			t.Fatalf("%s: %s", expression, err)
+		}
+
+		if got != want {
+			t.Fatalf("%s: want %v, got %v", expression, want, got)
+		}
+	}
+}
+
+func TestFilterErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]int{
+		".NodeName ==":            13,
+		".NodeName == a &&":       18,
+		"(.NodeName == a":         16,
+		".NodeName = a":           11,
+		"node == a":               1,
+		".Unknown == a":           1,
+		`.NodeName == "a`:         14,
+		".NodeName =~ \"[a\"":     14,
+		".NodeName == a)":         15,
+		".NodeName == a .Evicted": 16,
+	}
+
+	for expression, column := range tests {
+		_, err := filter.Parse(expression, []string{"NodeName", "Evicted"})
+
+		var filterError *filter.Error
+		if !errors.As(err, &filterError) {
+			t.Fatalf("%s: want filter error, got %v", expression, err)
+		}
+
+		if filterError.Column != column {
+			t.Fatalf("%s: want column %d, got %d (%s)", expression, column, filterError.Column, err)
+		}
+	}
+}
+
+func TestFilterCompareError(t *testing.T) {
+	t.Parallel()
+
+	e, err := filter.Parse(".NodeName > 1Gi", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := e.Match(func(string) (string, error) { return "node-1", nil }); err == nil {
+		t.Fatal("want error comparing string with quantity")
+	}
+}
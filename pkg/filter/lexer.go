@@ -0,0 +1,191 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package filter
+
+import (
+	"strings"
+	"unicode"
+)
+
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenField
+	tokenString
+	tokenWord
+	tokenCompare
+	tokenAnd
+	tokenOr
+	tokenNot
+	tokenLeftParen
+	tokenRightParen
+)
+
+type token struct {
+	typ   tokenType
+	value string
+	// 1-based column of the first rune of token
+	column int
+}
+
+func (t token) String() string {
+	if t.typ == tokenEOF {
+		return "end of expression"
+	}
+
+	return `"` + t.value + `"`
+}
+
+type lexer struct {
+	input []rune
+	pos   int
+}
+
+func lex(text string) ([]token, error) {
+	l := lexer{input: []rune(text)}
+
+	tokens := make([]token, 0)
+
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, t)
+
+		if t.typ == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) peek(offset int) rune {
+	if l.pos+offset >= len(l.input) {
+		return 0
+	}
+
+	return l.input[l.pos+offset]
+}
+
+func (l *lexer) emit(typ tokenType, start int) token {
+	return token{typ: typ, value: string(l.input[start:l.pos]), column: start + 1}
+}
+
+func (l *lexer) next() (token, error) { //nolint:cyclop,funlen
+	for l.pos < len(l.input) && unicode.IsSpace(l.input[l.pos]) {
+		l.pos++
+	}
+
+	start := l.pos
+
+	if l.pos >= len(l.input) {
+		return token{typ: tokenEOF, column: start + 1}, nil
+	}
+
+	r := l.input[l.pos]
+
+	switch {
+	case r == '(':
+		l.pos++
+
+		return l.emit(tokenLeftParen, start), nil
+	case r == ')':
+		l.pos++
+
+		return l.emit(tokenRightParen, start), nil
+	case r == ',':
+		// comma is kept as alias of || for compatibility with old filter format
+		l.pos++
+
+		return l.emit(tokenOr, start), nil
+	case r == '&' && l.peek(1) == '&':
+		l.pos += 2
+
+		return l.emit(tokenAnd, start), nil
+	case r == '|' && l.peek(1) == '|':
+		l.pos += 2
+
+		return l.emit(tokenOr, start), nil
+	case r == '=' && (l.peek(1) == '=' || l.peek(1) == '~'),
+		r == '!' && (l.peek(1) == '=' || l.peek(1) == '~'),
+		(r == '<' || r == '>') && l.peek(1) == '=':
+		l.pos += 2
+
+		return l.emit(tokenCompare, start), nil
+	case r == '<' || r == '>':
+		l.pos++
+
+		return l.emit(tokenCompare, start), nil
+	case r == '!':
+		l.pos++
+
+		return l.emit(tokenNot, start), nil
+	case r == '.' && unicode.IsLetter(l.peek(1)):
+		l.pos++
+
+		for l.pos < len(l.input) && isFieldRune(l.input[l.pos]) {
+			l.pos++
+		}
+
+		return l.emit(tokenField, start), nil
+	case r == '"' || r == '\'':
+		return l.lexString(r)
+	case isWordRune(r):
+		for l.pos < len(l.input) && isWordRune(l.input[l.pos]) {
+			l.pos++
+		}
+
+		return l.emit(tokenWord, start), nil
+	default:
+		return token{}, newError(start+1, "unexpected character %q", r)
+	}
+}
+
+// lexString reads quoted string, quote character can be escaped with backslash.
+func (l *lexer) lexString(quote rune) (token, error) {
+	start := l.pos
+
+	var value strings.Builder
+
+	l.pos++
+
+	for l.pos < len(l.input) {
+		r := l.input[l.pos]
+
+		switch {
+		case r == '\\' && (l.peek(1) == quote || l.peek(1) == '\\'):
+			value.WriteRune(l.peek(1))
+			l.pos += 2
+		case r == quote:
+			l.pos++
+
+			return token{typ: tokenString, value: value.String(), column: start + 1}, nil
+		default:
+			value.WriteRune(r)
+			l.pos++
+		}
+	}
+
+	return token{}, newError(start+1, "unterminated string")
+}
+
+func isFieldRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || r == '_' || r == '.'
+}
+
+// words are unquoted values like node names, numbers or quantities (1Gi, 500m, -10).
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r) || strings.ContainsRune("-_./:+*", r)
+}
@@ -0,0 +1,149 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package findings
+
+import (
+	"fmt"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// Level of rule, names are the same as in SARIF.
+type Level string
+
+const (
+	LevelError   = Level("error")
+	LevelWarning = Level("warning")
+	LevelNote    = Level("note")
+)
+
+// Rule that is checked on every container.
+type Rule struct {
+	ID          string
+	Description string
+	Level       Level
+}
+
+// Result of rule on container, message describes failure.
+type Result struct {
+	Rule    *Rule
+	Pod     *types.PodResources
+	Failed  bool
+	Message string
+}
+
+// check returns true and message if container fails rule.
+type check func(pod *types.PodResources) (bool, string)
+
+type builtinRule struct {
+	Rule
+	check check
+}
+
+//nolint:gochecknoglobals
+var builtinRules = []builtinRule{
+	{
+		Rule:  Rule{ID: "NoMemoryRequest", Description: "memory request is not set", Level: LevelWarning},
+		check: func(pod *types.PodResources) (bool, string) { return utils.QuantityToFloat(pod.MemoryRequest) == 0, "" },
+	},
+	{
+		Rule:  Rule{ID: "NoCPURequest", Description: "cpu request is not set", Level: LevelWarning},
+		check: func(pod *types.PodResources) (bool, string) { return utils.QuantityToFloat(pod.CPURequest) == 0, "" },
+	},
+	{
+		Rule:  Rule{ID: "OOMKilled", Description: "container was OOMKilled", Level: LevelError},
+		check: checkOOMKilled,
+	},
+	{
+		Rule:  Rule{ID: "Evicted", Description: "pod was evicted", Level: LevelWarning},
+		check: func(pod *types.PodResources) (bool, string) { return pod.Evicted, "" },
+	},
+	{
+		Rule:  Rule{ID: "BadMemoryRequest", Description: "memory request is far from recommendation", Level: LevelNote},
+		check: checkScore(types.MemoryResourcePlaningType),
+	},
+	{
+		Rule:  Rule{ID: "BadCPURequest", Description: "cpu request is far from recommendation", Level: LevelNote},
+		check: checkScore(types.CPUResourcePlaningType),
+	},
+}
+
+// GetRules returns rules that are checked on containers.
+func GetRules() []*Rule {
+	result := make([]*Rule, 0, len(builtinRules))
+
+	for i := range builtinRules {
+		result = append(result, &builtinRules[i].Rule)
+	}
+
+	return result
+}
+
+// Check returns results of all rules on all containers.
+func Check(pods []*types.PodResources) []Result {
+	result := make([]Result, 0)
+
+	for _, pod := range pods {
+		for i := range builtinRules {
+			rule := &builtinRules[i]
+
+			failed, message := rule.check(pod)
+			if len(message) == 0 {
+				message = rule.Description
+			}
+
+			result = append(result, Result{Rule: &rule.Rule, Pod: pod, Failed: failed, Message: message})
+		}
+	}
+
+	return result
+}
+
+func checkOOMKilled(pod *types.PodResources) (bool, string) {
+	recomendation := pod.GetRecomendation()
+
+	if !pod.OOMKilled && (recomendation == nil || !recomendation.OOMKilled) {
+		return false, ""
+	}
+
+	return true, fmt.Sprintf("container was OOMKilled with memory limit %s", pod.MemoryLimit)
+}
+
+func checkScore(planingType types.ResourcePlaningType) check {
+	return func(pod *types.PodResources) (bool, string) {
+		if pod.GetRecomendation() == nil {
+			return false, ""
+		}
+
+		score, reason := pod.ExplainScore(planingType)
+		if score != types.BadResourcePlaningResult {
+			return false, ""
+		}
+
+		return true, fmt.Sprintf("%s request planing score is %s, %s", planingType, score.Name(), reason)
+	}
+}
+
+// Failed returns only failed results.
+func Failed(results []Result) []Result {
+	failed := make([]Result, 0)
+
+	for _, result := range results {
+		if result.Failed {
+			failed = append(failed, result)
+		}
+	}
+
+	return failed
+}
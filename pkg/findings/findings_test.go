@@ -0,0 +1,147 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package findings_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/findings"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func testPods() []*types.PodResources {
+	return []*types.PodResources{
+		{
+			Namespace:     "default",
+			PodName:       "app-7d9f8-abcde",
+			PodTemplate:   "app-",
+			OwnerKind:     "ReplicaSet",
+			OwnerName:     "app-7d9f8",
+			ContainerName: "app",
+			MemoryRequest: "0",
+			CPURequest:    "100m",
+			OOMKilled:     true,
+		},
+		{
+			Namespace:     "default",
+			PodName:       "job",
+			ContainerName: "job",
+			MemoryRequest: "100Mi",
+			CPURequest:    "100m",
+		},
+	}
+}
+
+func TestCheck(t *testing.T) {
+	t.Parallel()
+
+	failed := findings.Failed(findings.Check(testPods()))
+
+	got := make([]string, 0)
+
+	for _, result := range failed {
+		got = append(got, result.Pod.GetWorkloadName()+" "+result.Rule.ID)
+	}
+
+	want := "default/Deployment/app NoMemoryRequest,default/Deployment/app OOMKilled"
+
+	if strings.Join(got, ",") != want {
+		t.Fatalf("want %s, got %s", want, strings.Join(got, ","))
+	}
+}
+
+func TestWriteSARIF(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	if err := findings.WriteSARIF(&b, findings.GetRules(), findings.Check(testPods()), "test"); err != nil {
+		t.Fatal(err)
+	}
+
+	result := struct {
+		Runs []struct {
+			Results []struct {
+				RuleID    string `json:"ruleId"`
+				Locations []struct {
+					PhysicalLocation struct {
+						ArtifactLocation struct {
+							URI string `json:"uri"`
+						} `json:"artifactLocation"`
+					} `json:"physicalLocation"`
+					LogicalLocations []struct {
+						FullyQualifiedName string `json:"fullyQualifiedName"`
+					} `json:"logicalLocations"`
+				} `json:"locations"`
+			} `json:"results"`
+		} `json:"runs"`
+	}{}
+
+	if err := json.Unmarshal(b.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(result.Runs) != 1 || len(result.Runs[0].Results) != 2 {
+		t.Fatalf("want 2 results, got %s", b.String())
+	}
+
+	location := result.Runs[0].Results[1].Locations[0]
+
+	if got := location.LogicalLocations[0].FullyQualifiedName; got != "default/Deployment/app/app" {
+		t.Fatalf("want default/Deployment/app/app location, got %s", got)
+	}
+
+	if got := location.PhysicalLocation.ArtifactLocation.URI; got != "default/Deployment/app" {
+		t.Fatalf("want default/Deployment/app artifact, got %s", got)
+	}
+}
+
+func TestWriteJUnit(t *testing.T) {
+	t.Parallel()
+
+	var b bytes.Buffer
+
+	// memory request is far from recommendation
+	pod := &types.PodResources{
+		Namespace:     "default",
+		PodName:       "web",
+		ContainerName: "web",
+		MemoryRequest: "1Gi",
+		CPURequest:    "100m",
+	}
+
+	pod.SetRecomendation(&types.Recomendations{MemoryRequest: "100Mi", CPURequest: "100m"})
+
+	if err := findings.WriteJUnit(&b, findings.GetRules(), findings.Check(append(testPods(), pod))); err != nil {
+		t.Fatal(err)
+	}
+
+	result := struct {
+		Tests    int `xml:"tests,attr"`
+		Failures int `xml:"failures,attr"`
+		Skipped  int `xml:"skipped,attr"`
+	}{}
+
+	if err := xml.Unmarshal(b.Bytes(), &result); err != nil {
+		t.Fatal(err)
+	}
+
+	// 6 rules on 3 containers, notes are skipped
+	if result.Tests != 18 || result.Failures != 2 || result.Skipped != 1 {
+		t.Fatalf("want 18 tests, 2 failures and 1 skipped, got %d, %d and %d", result.Tests, result.Failures, result.Skipped)
+	}
+}
@@ -0,0 +1,114 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package findings
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name         `xml:"testsuites"`
+	Name     string           `xml:"name,attr"`
+	Tests    int              `xml:"tests,attr"`
+	Failures int              `xml:"failures,attr"`
+	Skipped  int              `xml:"skipped,attr"`
+	Suites   []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	Failures  int             `xml:"failures,attr"`
+	Skipped   int             `xml:"skipped,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Failure   *junitFailure `xml:"failure,omitempty"`
+	Skipped   *junitSkipped `xml:"skipped,omitempty"`
+}
+
+type junitSkipped struct {
+	Message string `xml:"message,attr"`
+}
+
+type junitFailure struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// WriteJUnit writes results in JUnit XML format, every rule is test suite and every container is test case,
+// failed results with error and warning levels are failures with level in type,
+// failed results with note level are skipped, so notes do not make build unstable.
+func WriteJUnit(out io.Writer, rules []*Rule, results []Result) error {
+	suites := junitTestSuites{Name: toolName}
+	suiteIndex := make(map[string]int)
+
+	for _, rule := range rules {
+		suiteIndex[rule.ID] = len(suites.Suites)
+		suites.Suites = append(suites.Suites, junitTestSuite{Name: rule.ID, TestCases: make([]junitTestCase, 0)})
+	}
+
+	for _, result := range results {
+		suite := &suites.Suites[suiteIndex[result.Rule.ID]]
+
+		testCase := junitTestCase{
+			Name:      result.Pod.PodName + "/" + result.Pod.ContainerName,
+			ClassName: result.Pod.GetWorkloadName(),
+		}
+
+		switch {
+		case result.Failed && result.Rule.Level == LevelNote:
+			testCase.Skipped = &junitSkipped{Message: result.Message}
+
+			suite.Skipped++
+			suites.Skipped++
+		case result.Failed:
+			testCase.Failure = &junitFailure{
+				Message: result.Message,
+				Type:    string(result.Rule.Level),
+				Text:    result.Rule.Description,
+			}
+
+			suite.Failures++
+			suites.Failures++
+		}
+
+		suite.Tests++
+		suites.Tests++
+		suite.TestCases = append(suite.TestCases, testCase)
+	}
+
+	if _, err := io.WriteString(out, xml.Header); err != nil {
+		return errors.Wrap(err, "error writing junit")
+	}
+
+	encoder := xml.NewEncoder(out)
+	encoder.Indent("", "  ")
+
+	if err := encoder.Encode(suites); err != nil {
+		return errors.Wrap(err, "error encoding junit")
+	}
+
+	if _, err := io.WriteString(out, "\n"); err != nil {
+		return errors.Wrap(err, "error writing junit")
+	}
+
+	return nil
+}
@@ -0,0 +1,190 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package lint
+
+import (
+	"os"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/filter"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Level of rule, failed rules with error level fail lint.
+type Level string
+
+const (
+	LevelError   = Level("error")
+	LevelWarning = Level("warning")
+)
+
+// Rule that containers must match.
+type Rule struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Level       Level  `yaml:"level"`
+	// filter expression of containers that rule is applied to, all containers if empty
+	Selector string `yaml:"selector"`
+	// filter expression that container must match
+	Expr     string `yaml:"expr"`
+	selector *filter.Expression
+	expr     *filter.Expression
+}
+
+// Rules file.
+type Rules struct {
+	Rules []*Rule `yaml:"rules"`
+}
+
+// Container that does not match rule.
+type Violation struct {
+	Rule *Rule
+	Pod  *types.PodResources
+}
+
+// Load reads and parses rules file.
+func Load(path string) ([]*Rule, error) {
+	rulesByte, err := os.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrapf(err, "error opening rules %s", path)
+	}
+
+	return Parse(rulesByte)
+}
+
+// Parse parses rules in yaml, rules without level have error level.
+func Parse(rulesByte []byte) ([]*Rule, error) {
+	rules := Rules{}
+
+	if err := yaml.Unmarshal(rulesByte, &rules); err != nil {
+		return nil, errors.Wrap(err, "error unmarshal rules")
+	}
+
+	if len(rules.Rules) == 0 {
+		return nil, errors.New("no rules found")
+	}
+
+	names := make(map[string]bool)
+
+	for _, rule := range rules.Rules {
+		if len(rule.Name) == 0 {
+			return nil, errors.New("rule name is empty")
+		}
+
+		if names[rule.Name] {
+			return nil, errors.Errorf("duplicate rule %s", rule.Name)
+		}
+
+		names[rule.Name] = true
+
+		if err := rule.parse(); err != nil {
+			return nil, errors.Wrap(err, rule.Name)
+		}
+	}
+
+	return rules.Rules, nil
+}
+
+func (r *Rule) parse() error {
+	switch r.Level {
+	case "":
+		r.Level = LevelError
+	case LevelError, LevelWarning:
+	default:
+		return errors.Errorf("unknown level %s", r.Level)
+	}
+
+	if len(r.Expr) == 0 {
+		return errors.New("expr is empty")
+	}
+
+	var err error
+
+	r.expr, err = filter.Parse(r.Expr, types.GetFieldNames())
+	if err != nil {
+		return errors.Wrap(err, "error parsing expr")
+	}
+
+	if len(r.Selector) > 0 {
+		r.selector, err = filter.Parse(r.Selector, types.GetFieldNames())
+		if err != nil {
+			return errors.Wrap(err, "error parsing selector")
+		}
+	}
+
+	return nil
+}
+
+// Fields returns fields that are used in rule.
+func (r *Rule) Fields() []string {
+	result := r.expr.Fields()
+
+	if r.selector != nil {
+		result = append(result, r.selector.Fields()...)
+	}
+
+	return result
+}
+
+// Check returns true if container is not selected by rule or matches rule expression.
+func (r *Rule) Check(pod *types.PodResources) (bool, error) {
+	if r.selector != nil {
+		selected, err := r.selector.Match(pod.GetFieldValue)
+		if err != nil {
+			return false, errors.Wrap(err, "error matching selector")
+		}
+
+		if !selected {
+			return true, nil
+		}
+	}
+
+	match, err := r.expr.Match(pod.GetFieldValue)
+	if err != nil {
+		return false, errors.Wrap(err, "error matching expr")
+	}
+
+	return match, nil
+}
+
+// Evaluate returns violations of rules by containers.
+func Evaluate(rules []*Rule, pods []*types.PodResources) ([]Violation, error) {
+	result := make([]Violation, 0)
+
+	for _, rule := range rules {
+		for _, pod := range pods {
+			ok, err := rule.Check(pod)
+			if err != nil {
+				return nil, errors.Wrapf(err, "error checking rule %s on %s", rule.Name, pod.GetPodNamespaceName())
+			}
+
+			if !ok {
+				result = append(result, Violation{Rule: rule, Pod: pod})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// HasErrors returns true if there are violations of rules with error level.
+func HasErrors(violations []Violation) bool {
+	for _, violation := range violations {
+		if violation.Rule.Level == LevelError {
+			return true
+		}
+	}
+
+	return false
+}
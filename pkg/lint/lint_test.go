@@ -0,0 +1,100 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package lint_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/lint"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+const rules = `
+rules:
+- name: memory-request
+  description: every container must have memory request
+  expr: .MemoryRequest != 0
+- name: memory-limit
+  level: warning
+  expr: .MemoryLimit == .MemoryRequest
+- name: cpu-limit-ratio
+  expr: .CPULimitRequestRatio == "" || .CPULimitRequestRatio <= 4
+- name: prod-best-effort
+  selector: .Namespace =~ "prod-.*"
+  expr: .QoS != BestEffort
+`
+
+func TestEvaluate(t *testing.T) {
+	t.Parallel()
+
+	parsed, err := lint.Parse([]byte(rules))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	pods := []*types.PodResources{
+		{Namespace: "prod-a", PodName: "ok", MemoryRequest: "1Gi", MemoryLimit: "1024Mi", CPURequest: "1", CPULimit: "2"},
+		{Namespace: "prod-a", PodName: "best-effort", MemoryRequest: "0", MemoryLimit: "0", QoS: "BestEffort"},
+		{Namespace: "dev", PodName: "ratio", MemoryRequest: "1Gi", MemoryLimit: "2Gi", CPURequest: "100m", CPULimit: "1"},
+	}
+
+	violations, err := lint.Evaluate(parsed, pods)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got := make(map[string]string)
+
+	for _, violation := range violations {
+		got[violation.Pod.PodName+"/"+violation.Rule.Name] = string(violation.Rule.Level)
+	}
+
+	want := map[string]string{
+		"best-effort/memory-request":   "error",
+		"best-effort/prod-best-effort": "error",
+		"ratio/memory-limit":           "warning",
+		"ratio/cpu-limit-ratio":        "error",
+	}
+
+	if len(got) != len(want) {
+		t.Fatalf("want %v, got %v", want, got)
+	}
+
+	for key, level := range want {
+		if got[key] != level {
+			t.Fatalf("want %s %s, got %v", key, level, got)
+		}
+	}
+
+	if !lint.HasErrors(violations) {
+		t.Fatal("want errors")
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	t.Parallel()
+
+	invalid := []string{
+		"rules: []",
+		"rules: [{name: a}]",
+		"rules: [{name: a, expr: .Unknown == 1}]",
+		"rules: [{name: a, expr: .QoS == BestEffort, level: fatal}]",
+		"rules: [{name: a, expr: .QoS == BestEffort}, {name: a, expr: .QoS == BestEffort}]",
+	}
+
+	for _, rules := range invalid {
+		if _, err := lint.Parse([]byte(rules)); err == nil {
+			t.Fatalf("want error for %s", rules)
+		}
+	}
+}
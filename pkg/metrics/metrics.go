@@ -0,0 +1,115 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/api"
+	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
+	promConfig "github.com/prometheus/common/config"
+	"github.com/prometheus/common/model"
+	log "github.com/sirupsen/logrus"
+)
+
+func getAPI() (v1.API, error) {
+	prometheusConfig := api.Config{
+		Address: *config.Get().PrometheusURL,
+	}
+
+	if len(*config.Get().PrometheusUser) > 0 {
+		prometheusConfig.RoundTripper = promConfig.NewBasicAuthRoundTripper(
+			*config.Get().PrometheusUser,
+			promConfig.Secret(*config.Get().PrometheusPassword),
+			"",
+			"",
+			api.DefaultRoundTripper,
+		)
+	}
+
+	client, err := api.NewClient(prometheusConfig)
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating client")
+	}
+
+	return v1.NewAPI(client), nil
+}
+
+// Query executes instant query.
+func Query(query string) (model.Vector, error) {
+	log.Debugf("query: %s", query)
+
+	v1api, err := getAPI()
+	if err != nil {
+		return nil, err
+	}
+
+	result, warnings, err := v1api.Query(context.Background(), query, time.Now())
+	if err != nil {
+		return nil, errors.Wrap(err, "error creating client")
+	}
+
+	if len(warnings) > 0 {
+		log.Warn(warnings)
+	}
+
+	v, ok := result.(model.Vector)
+	if !ok {
+		return nil, errors.New("assertion error")
+	}
+
+	return v, nil
+}
+
+// QueryRange executes range query.
+func QueryRange(query string, start, end time.Time, step time.Duration) (model.Matrix, error) {
+	log.Debugf("range query: %s", query)
+
+	v1api, err := getAPI()
+	if err != nil {
+		return nil, err
+	}
+
+	result, warnings, err := v1api.QueryRange(context.Background(), query, v1.Range{
+		Start: start,
+		End:   end,
+		Step:  step,
+	})
+	if err != nil {
+		return nil, errors.Wrap(err, "error executing range query")
+	}
+
+	if len(warnings) > 0 {
+		log.Warn(warnings)
+	}
+
+	m, ok := result.(model.Matrix)
+	if !ok {
+		return nil, errors.New("assertion error")
+	}
+
+	return m, nil
+}
+
+// GetRetention returns prometheus retention period.
+func GetRetention() (time.Duration, error) {
+	retention, err := model.ParseDuration(*config.Get().PrometheusRetention)
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing retention")
+	}
+
+	return time.Duration(retention), nil
+}
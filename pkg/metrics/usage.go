@@ -0,0 +1,74 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package metrics
+
+import (
+	"fmt"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// UsageKey returns key of container in usage results.
+func UsageKey(namespace, pod, container string) string {
+	return fmt.Sprintf("%s/%s/%s", namespace, pod, container)
+}
+
+// GetUsage returns average usage of all containers during retention period.
+func GetUsage() (map[string]*types.Usage, error) {
+	selector := `container!=""`
+
+	if len(*config.Get().Namespace) > 0 {
+		selector += fmt.Sprintf(`,namespace="%s"`, *config.Get().Namespace)
+	}
+
+	if len(*config.Get().PrometheusGroupField) > 0 {
+		selector += fmt.Sprintf(`,%s=~"%s"`, *config.Get().PrometheusGroupField, *config.Get().PrometheusGroupValue)
+	}
+
+	memoryQuery := fmt.Sprintf(`max by (namespace,pod,container) (avg_over_time(container_memory_working_set_bytes{%s}[%s]))`, selector, *config.Get().PrometheusRetention) //nolint:lll
+	cpuQuery := fmt.Sprintf(`sum by (namespace,pod,container) (rate(container_cpu_usage_seconds_total{%s}[%s]))`, selector, *config.Get().PrometheusRetention)              //nolint:lll
+
+	result := make(map[string]*types.Usage)
+
+	get := func(namespace, pod, container string) *types.Usage {
+		key := UsageKey(namespace, pod, container)
+
+		if _, ok := result[key]; !ok {
+			result[key] = &types.Usage{}
+		}
+
+		return result[key]
+	}
+
+	memory, err := Query(memoryQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting memory usage")
+	}
+
+	for _, sample := range memory {
+		get(string(sample.Metric["namespace"]), string(sample.Metric["pod"]), string(sample.Metric["container"])).Memory = float64(sample.Value) //nolint:lll
+	}
+
+	cpu, err := Query(cpuQuery)
+	if err != nil {
+		return nil, errors.Wrap(err, "error getting cpu usage")
+	}
+
+	for _, sample := range cpu {
+		get(string(sample.Metric["namespace"]), string(sample.Metric["pod"]), string(sample.Metric["container"])).CPU = float64(sample.Value) //nolint:lll
+	}
+
+	return result, nil
+}
@@ -0,0 +1,142 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package nodes
+
+import (
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// Node allocation and usage, cpu in cores, memory in bytes.
+type Node struct {
+	NodeName                string
+	NodePool                string
+	Pods                    int
+	AllocatableCPU          float64
+	CPURequest              float64
+	CPULimit                float64
+	CPUUsage                float64
+	RecomendedCPURequest    float64
+	AllocatableMemory       float64
+	MemoryRequest           float64
+	MemoryLimit             float64
+	MemoryUsage             float64
+	RecomendedMemoryRequest float64
+}
+
+// CPUOvercommit returns sum of cpu limits divided by allocatable cpu.
+func (n *Node) CPUOvercommit() float64 {
+	if n.AllocatableCPU == 0 {
+		return 0
+	}
+
+	return n.CPULimit / n.AllocatableCPU
+}
+
+// MemoryOvercommit returns sum of memory limits divided by allocatable memory.
+func (n *Node) MemoryOvercommit() float64 {
+	if n.AllocatableMemory == 0 {
+		return 0
+	}
+
+	return n.MemoryLimit / n.AllocatableMemory
+}
+
+func (n *Node) add(pod *types.PodResources) {
+	cpuRequest := utils.QuantityToFloat(pod.CPURequest)
+	memoryRequest := utils.QuantityToFloat(pod.MemoryRequest)
+
+	recomendedCPURequest := cpuRequest
+	recomendedMemoryRequest := memoryRequest
+
+	if recomendation := pod.GetRecomendation(); recomendation != nil {
+		if len(recomendation.CPURequest) > 0 {
+			recomendedCPURequest = utils.QuantityToFloat(recomendation.CPURequest)
+		}
+
+		if len(recomendation.MemoryRequest) > 0 {
+			recomendedMemoryRequest = utils.QuantityToFloat(recomendation.MemoryRequest)
+		}
+	}
+
+	n.CPURequest += cpuRequest
+	n.CPULimit += utils.QuantityToFloat(pod.CPULimit)
+	n.RecomendedCPURequest += recomendedCPURequest
+	n.MemoryRequest += memoryRequest
+	n.MemoryLimit += utils.QuantityToFloat(pod.MemoryLimit)
+	n.RecomendedMemoryRequest += recomendedMemoryRequest
+
+	if pod.Usage != nil {
+		n.CPUUsage += pod.Usage.CPU
+		n.MemoryUsage += pod.Usage.Memory
+	}
+}
+
+// Get returns allocation of nodes sorted by node pool and name, evicted, succeeded and failed pods are ignored.
+func Get(nodes []*types.NodeResources, pods []*types.PodResources) []*Node {
+	result := make([]*Node, 0, len(nodes))
+	byName := make(map[string]*Node)
+
+	for _, node := range nodes {
+		item := Node{
+			NodeName:          node.NodeName,
+			NodePool:          node.NodePool,
+			AllocatableCPU:    utils.QuantityToFloat(node.AllocatableCPU),
+			AllocatableMemory: utils.QuantityToFloat(node.AllocatableMemory),
+		}
+
+		result = append(result, &item)
+		byName[node.NodeName] = &item
+	}
+
+	podsOnNode := make(map[string]map[string]bool)
+
+	for _, pod := range pods {
+		node, ok := byName[pod.NodeName]
+		if !ok || pod.Evicted {
+			continue
+		}
+
+		// completed pods do not allocate resources of node
+		if pod.Phase != "Running" && pod.Phase != "Pending" {
+			continue
+		}
+
+		if _, ok := podsOnNode[pod.NodeName]; !ok {
+			podsOnNode[pod.NodeName] = make(map[string]bool)
+		}
+
+		podsOnNode[pod.NodeName][pod.GetPodNamespaceName()] = true
+
+		// init containers are not running with other containers
+		if !pod.InitContainer {
+			node.add(pod)
+		}
+	}
+
+	for _, node := range result {
+		node.Pods = len(podsOnNode[node.NodeName])
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].NodePool != result[j].NodePool {
+			return result[i].NodePool < result[j].NodePool
+		}
+
+		return result[i].NodeName < result[j].NodeName
+	})
+
+	return result
+}
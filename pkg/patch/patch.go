@@ -0,0 +1,227 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package patch
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// kinds of workloads with pod template that can be patched.
+var patchableKinds = map[string]string{
+	"Deployment":  "deployment",
+	"StatefulSet": "statefulset",
+	"DaemonSet":   "daemonset",
+	"ReplicaSet":  "replicaset",
+}
+
+// Patch of workload with recomended resources of containers.
+type Patch struct {
+	Namespace  string
+	Kind       string
+	Name       string
+	Containers []Container
+}
+
+// Container with recomended resources, nil value removes resource.
+type Container struct {
+	Name          string
+	InitContainer bool
+	Requests      map[string]*string
+	Limits        map[string]*string
+}
+
+// Skipped container that can not be patched.
+type Skipped struct {
+	Pod    *types.PodResources
+	Reason string
+}
+
+// Build returns patches of workloads with recomendations of pods, recomendations of every
+// container of workload are used once, pods that are not confident or without workload are skipped.
+func Build(pods []*types.PodResources, confident func(*types.PodResources) bool) ([]*Patch, []Skipped) {
+	patches := make(map[string]*Patch)
+	containers := make(map[string]bool)
+	skipped := make([]Skipped, 0)
+
+	for _, pod := range pods {
+		workload := pod.GetWorkloadName()
+
+		if containers[workload+"/"+pod.ContainerName] {
+			continue
+		}
+
+		containers[workload+"/"+pod.ContainerName] = true
+
+		namespace, kind, name := splitWorkload(workload)
+
+		if _, ok := patchableKinds[kind]; !ok {
+			skipped = append(skipped, Skipped{Pod: pod, Reason: fmt.Sprintf("%s can not be patched", kind)})
+
+			continue
+		}
+
+		container, ok := getContainer(pod)
+		if !ok {
+			skipped = append(skipped, Skipped{Pod: pod, Reason: "no recomendation"})
+
+			continue
+		}
+
+		if !confident(pod) {
+			skipped = append(skipped, Skipped{Pod: pod, Reason: "recomendation is not confident"})
+
+			continue
+		}
+
+		workloadPatch, ok := patches[workload]
+		if !ok {
+			workloadPatch = &Patch{Namespace: namespace, Kind: kind, Name: name}
+			patches[workload] = workloadPatch
+		}
+
+		workloadPatch.Containers = append(workloadPatch.Containers, container)
+	}
+
+	result := make([]*Patch, 0, len(patches))
+
+	for _, workloadPatch := range patches {
+		sort.Slice(workloadPatch.Containers, func(i, j int) bool {
+			return workloadPatch.Containers[i].Name < workloadPatch.Containers[j].Name
+		})
+
+		result = append(result, workloadPatch)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].String() < result[j].String()
+	})
+
+	return result, skipped
+}
+
+func splitWorkload(workload string) (string, string, string) {
+	const workloadParts = 3
+
+	parts := strings.SplitN(workload, "/", workloadParts)
+	if len(parts) != workloadParts {
+		return "", "", workload
+	}
+
+	return parts[0], parts[1], parts[2]
+}
+
+// getContainer returns container with recomended resources, false if there is no recomendation.
+func getContainer(pod *types.PodResources) (Container, bool) {
+	container := Container{
+		Name:          pod.ContainerName,
+		InitContainer: pod.InitContainer,
+		Requests:      make(map[string]*string),
+		Limits:        make(map[string]*string),
+	}
+
+	recomendation := pod.GetRecomendation()
+	if recomendation == nil {
+		return container, false
+	}
+
+	set := func(resources map[string]*string, name, value string) {
+		if len(value) > 0 {
+			resources[name] = &value
+		}
+	}
+
+	set(container.Requests, "memory", recomendation.MemoryRequest)
+	set(container.Requests, "cpu", recomendation.CPURequest)
+	set(container.Limits, "memory", recomendation.MemoryLimit)
+	set(container.Limits, "cpu", recomendation.CPULimit)
+
+	if recomendation.RemoveCPULimit {
+		container.Limits["cpu"] = nil
+	}
+
+	return container, len(container.Requests) > 0 || len(container.Limits) > 0
+}
+
+func (p *Patch) String() string {
+	return fmt.Sprintf("%s/%s/%s", p.Namespace, p.Kind, p.Name)
+}
+
+// JSON returns strategic merge patch of workload, init containers are patched in initContainers.
+func (p *Patch) JSON() ([]byte, error) {
+	podSpec := make(map[string]interface{})
+
+	for _, container := range p.Containers {
+		resources := make(map[string]interface{})
+
+		if len(container.Requests) > 0 {
+			resources["requests"] = container.Requests
+		}
+
+		if len(container.Limits) > 0 {
+			resources["limits"] = container.Limits
+		}
+
+		key := "containers"
+		if container.InitContainer {
+			key = "initContainers"
+		}
+
+		containers, _ := podSpec[key].([]map[string]interface{})
+
+		podSpec[key] = append(containers, map[string]interface{}{
+			"name":      container.Name,
+			"resources": resources,
+		})
+	}
+
+	patch := map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"spec": podSpec,
+			},
+		},
+	}
+
+	result, err := json.Marshal(patch)
+	if err != nil {
+		return nil, errors.Wrap(err, "error marshal patch")
+	}
+
+	return result, nil
+}
+
+// Write writes kubectl commands that apply patches.
+func Write(out io.Writer, patches []*Patch) error {
+	for _, workloadPatch := range patches {
+		value, err := workloadPatch.JSON()
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(out, "kubectl -n %s patch %s %s --type=strategic -p '%s'\n",
+			workloadPatch.Namespace,
+			patchableKinds[workloadPatch.Kind],
+			workloadPatch.Name,
+			value,
+		)
+	}
+
+	return nil
+}
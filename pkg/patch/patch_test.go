@@ -0,0 +1,89 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package patch_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/patch"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func newPod(podName, container, ownerKind string, recomendation *types.Recomendations) *types.PodResources {
+	pod := &types.PodResources{
+		Namespace:     "default",
+		PodName:       podName,
+		PodTemplate:   "app-",
+		ContainerName: container,
+		OwnerKind:     ownerKind,
+		OwnerName:     "app-5d4f8",
+	}
+
+	if recomendation != nil {
+		pod.SetRecomendation(recomendation)
+	}
+
+	return pod
+}
+
+func TestBuild(t *testing.T) {
+	t.Parallel()
+
+	recomendation := &types.Recomendations{MemoryRequest: "150Mi", CPURequest: "100m", RemoveCPULimit: true}
+
+	initContainer := newPod("app-1", "init", "ReplicaSet", &types.Recomendations{CPURequest: "50m"})
+	initContainer.InitContainer = true
+
+	pods := []*types.PodResources{
+		newPod("app-1", "app", "ReplicaSet", recomendation),
+		newPod("app-2", "app", "ReplicaSet", recomendation),
+		newPod("app-1", "sidecar", "ReplicaSet", &types.Recomendations{MemoryLimit: "64Mi"}),
+		newPod("app-1", "low", "ReplicaSet", &types.Recomendations{CPURequest: "10m"}),
+		newPod("app-1", "none", "ReplicaSet", nil),
+		initContainer,
+		newPod("job-1", "job", "Job", recomendation),
+	}
+
+	patches, skipped := patch.Build(pods, func(pod *types.PodResources) bool {
+		return pod.ContainerName != "low"
+	})
+
+	if len(patches) != 1 || patches[0].String() != "default/Deployment/app" {
+		t.Fatalf("want patch of default/Deployment/app, got %v", patches)
+	}
+
+	if len(patches[0].Containers) != 3 {
+		t.Fatalf("want 3 containers, got %d", len(patches[0].Containers))
+	}
+
+	if len(skipped) != 3 {
+		t.Fatalf("want 3 skipped containers, got %d", len(skipped))
+	}
+
+	var b bytes.Buffer
+
+	if err := patch.Write(&b, patches); err != nil {
+		t.Fatal(err)
+	}
+
+	want := `kubectl -n default patch deployment app --type=strategic -p '{"spec":{"template":{"spec":{"containers":[` +
+		`{"name":"app","resources":{"limits":{"cpu":null},"requests":{"cpu":"100m","memory":"150Mi"}}},` +
+		`{"name":"sidecar","resources":{"limits":{"memory":"64Mi"}}}],` +
+		`"initContainers":[{"name":"init","resources":{"requests":{"cpu":"50m"}}}]}}}}'`
+
+	if got := strings.TrimSpace(b.String()); got != want {
+		t.Fatalf("want\n%s\ngot\n%s", want, got)
+	}
+}
@@ -0,0 +1,260 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package policy
+
+import (
+	"math"
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Name of proposed LimitRange and ResourceQuota.
+const Name = "default-resources"
+
+const (
+	percents    = 100
+	milliCores  = 1000
+	bytesInMiB  = 1 << 20
+	halfDivisor = 2
+)
+
+// Proposal of LimitRange and ResourceQuota of namespace.
+type Proposal struct {
+	Namespace string
+	// unique containers of workloads used for default values
+	Containers int
+	// containers without confident recomendation, current resources are used
+	NotConfident  int
+	LimitRange    *corev1.LimitRange
+	ResourceQuota *corev1.ResourceQuota
+}
+
+// resources of container, recomended or current, cores and bytes.
+type containerResources struct {
+	cpuRequest, memoryRequest, cpuLimit, memoryLimit float64
+}
+
+type namespaceResources struct {
+	containers   map[string]containerResources
+	notConfident map[string]bool
+	total        containerResources
+	// all containers have limit, otherwise limits in quota are not proposed
+	allCPULimits, allMemoryLimits bool
+}
+
+// Propose returns LimitRange with median resources of containers as defaults and ResourceQuota
+// with total resources of pods plus headroom percents, confident returns true if recomendation
+// of container can be used, otherwise current resources are used.
+func Propose(pods []*types.PodResources, headroom float64, confident func(*types.PodResources) bool) []*Proposal {
+	namespaces := make(map[string]*namespaceResources)
+
+	for _, pod := range pods {
+		// init containers and finished pods are not counted in quota usage
+		if pod.InitContainer || (pod.Phase != "Running" && pod.Phase != "Pending") {
+			continue
+		}
+
+		namespace, ok := namespaces[pod.Namespace]
+		if !ok {
+			namespace = &namespaceResources{
+				containers:      make(map[string]containerResources),
+				notConfident:    make(map[string]bool),
+				allCPULimits:    true,
+				allMemoryLimits: true,
+			}
+
+			namespaces[pod.Namespace] = namespace
+		}
+
+		container := getContainerResources(pod, confident(pod))
+
+		key := pod.PodTemplate + "/" + pod.ContainerName
+		if len(pod.PodTemplate) == 0 {
+			key = pod.PodName + "/" + pod.ContainerName
+		}
+
+		namespace.containers[key] = container
+
+		if !confident(pod) {
+			namespace.notConfident[key] = true
+		}
+
+		namespace.total.cpuRequest += container.cpuRequest
+		namespace.total.memoryRequest += container.memoryRequest
+		namespace.total.cpuLimit += container.cpuLimit
+		namespace.total.memoryLimit += container.memoryLimit
+		namespace.allCPULimits = namespace.allCPULimits && container.cpuLimit > 0
+		namespace.allMemoryLimits = namespace.allMemoryLimits && container.memoryLimit > 0
+	}
+
+	result := make([]*Proposal, 0, len(namespaces))
+
+	for name, namespace := range namespaces {
+		result = append(result, &Proposal{
+			Namespace:     name,
+			Containers:    len(namespace.containers),
+			NotConfident:  len(namespace.notConfident),
+			LimitRange:    namespace.limitRange(name),
+			ResourceQuota: namespace.resourceQuota(name, headroom),
+		})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Namespace < result[j].Namespace
+	})
+
+	return result
+}
+
+func getContainerResources(pod *types.PodResources, confident bool) containerResources {
+	result := containerResources{
+		cpuRequest:    utils.QuantityToFloat(pod.CPURequest),
+		memoryRequest: utils.QuantityToFloat(pod.MemoryRequest),
+		cpuLimit:      utils.QuantityToFloat(pod.CPULimit),
+		memoryLimit:   utils.QuantityToFloat(pod.MemoryLimit),
+	}
+
+	recomendation := pod.GetRecomendation()
+	if !confident || recomendation == nil {
+		return result
+	}
+
+	recomended := func(value string, current float64) float64 {
+		if len(value) == 0 {
+			return current
+		}
+
+		return utils.QuantityToFloat(value)
+	}
+
+	result.cpuRequest = recomended(recomendation.CPURequest, result.cpuRequest)
+	result.memoryRequest = recomended(recomendation.MemoryRequest, result.memoryRequest)
+	result.cpuLimit = recomended(recomendation.CPULimit, result.cpuLimit)
+	result.memoryLimit = recomended(recomendation.MemoryLimit, result.memoryLimit)
+
+	if recomendation.RemoveCPULimit {
+		result.cpuLimit = 0
+	}
+
+	return result
+}
+
+func (n *namespaceResources) limitRange(namespace string) *corev1.LimitRange {
+	defaultRequest := make(corev1.ResourceList)
+	defaultLimit := make(corev1.ResourceList)
+
+	values := func(value func(containerResources) float64) []float64 {
+		result := make([]float64, 0)
+
+		for _, container := range n.containers {
+			if v := value(container); v > 0 {
+				result = append(result, v)
+			}
+		}
+
+		return result
+	}
+
+	cpuRequest, cpuRequestOK := median(values(func(c containerResources) float64 { return c.cpuRequest }))
+	memoryRequest, memoryRequestOK := median(values(func(c containerResources) float64 { return c.memoryRequest }))
+	cpuLimit, cpuLimitOK := median(values(func(c containerResources) float64 { return c.cpuLimit }))
+	memoryLimit, memoryLimitOK := median(values(func(c containerResources) float64 { return c.memoryLimit }))
+
+	if cpuRequestOK {
+		defaultRequest[corev1.ResourceCPU] = cpuQuantity(cpuRequest)
+	}
+
+	if memoryRequestOK {
+		defaultRequest[corev1.ResourceMemory] = memoryQuantity(memoryRequest)
+	}
+
+	// medians are calculated for different containers, api server rejects default limit that is less than request
+	if cpuLimitOK {
+		defaultLimit[corev1.ResourceCPU] = cpuQuantity(math.Max(cpuLimit, cpuRequest))
+	}
+
+	if memoryLimitOK {
+		defaultLimit[corev1.ResourceMemory] = memoryQuantity(math.Max(memoryLimit, memoryRequest))
+	}
+
+	item := corev1.LimitRangeItem{Type: corev1.LimitTypeContainer}
+
+	if len(defaultRequest) > 0 {
+		item.DefaultRequest = defaultRequest
+	}
+
+	if len(defaultLimit) > 0 {
+		item.Default = defaultLimit
+	}
+
+	return &corev1.LimitRange{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "LimitRange"},
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace},
+		Spec:       corev1.LimitRangeSpec{Limits: []corev1.LimitRangeItem{item}},
+	}
+}
+
+func (n *namespaceResources) resourceQuota(namespace string, headroom float64) *corev1.ResourceQuota {
+	factor := 1 + headroom/percents
+
+	hard := corev1.ResourceList{
+		corev1.ResourceRequestsCPU:    cpuQuantity(n.total.cpuRequest * factor),
+		corev1.ResourceRequestsMemory: memoryQuantity(n.total.memoryRequest * factor),
+	}
+
+	// containers without limit get LimitRange default limit, that is not in total
+	if n.allCPULimits {
+		hard[corev1.ResourceLimitsCPU] = cpuQuantity(n.total.cpuLimit * factor)
+	}
+
+	if n.allMemoryLimits {
+		hard[corev1.ResourceLimitsMemory] = memoryQuantity(n.total.memoryLimit * factor)
+	}
+
+	return &corev1.ResourceQuota{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "ResourceQuota"},
+		ObjectMeta: metav1.ObjectMeta{Name: Name, Namespace: namespace},
+		Spec:       corev1.ResourceQuotaSpec{Hard: hard},
+	}
+}
+
+// cpuQuantity returns cores rounded up to millicores.
+func cpuQuantity(value float64) resource.Quantity {
+	return *resource.NewMilliQuantity(int64(math.Ceil(value*milliCores)), resource.DecimalSI)
+}
+
+// memoryQuantity returns bytes rounded up to MiB.
+func memoryQuantity(value float64) resource.Quantity {
+	return *resource.NewQuantity(int64(math.Ceil(value/bytesInMiB))*bytesInMiB, resource.BinarySI)
+}
+
+func median(values []float64) (float64, bool) {
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	sort.Float64s(values)
+
+	middle := len(values) / halfDivisor
+
+	if len(values)%halfDivisor == 0 {
+		return (values[middle-1] + values[middle]) / halfDivisor, true
+	}
+
+	return values[middle], true
+}
@@ -0,0 +1,123 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package policy_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/policy"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestPropose(t *testing.T) {
+	t.Parallel()
+
+	pods := make([]*types.PodResources, 0)
+
+	for _, size := range []struct{ template, cpu, memory, recomended string }{
+		{"app-", "100m", "100Mi", "200m"},
+		{"app-", "100m", "100Mi", "200m"},
+		{"web-", "300m", "200Mi", ""},
+		{"db-", "1", "1Gi", ""},
+	} {
+		pod := &types.PodResources{
+			Namespace:     "default",
+			PodTemplate:   size.template,
+			ContainerName: "app",
+			Phase:         "Running",
+			CPURequest:    size.cpu,
+			MemoryRequest: size.memory,
+		}
+
+		pod.SetRecomendation(&types.Recomendations{CPURequest: size.recomended})
+
+		pods = append(pods, pod)
+	}
+
+	proposals := policy.Propose(pods, 50, func(pod *types.PodResources) bool {
+		return pod.PodTemplate != "db-"
+	})
+
+	if len(proposals) != 1 {
+		t.Fatalf("want 1 proposal, got %d", len(proposals))
+	}
+
+	proposal := proposals[0]
+
+	if proposal.Containers != 3 || proposal.NotConfident != 1 {
+		t.Fatalf("want 3 containers and 1 not confident, got %d and %d", proposal.Containers, proposal.NotConfident)
+	}
+
+	limits := proposal.LimitRange.Spec.Limits[0]
+
+	// median of 200m, 300m and 1
+	if got := limits.DefaultRequest.Cpu().String(); got != "300m" {
+		t.Fatalf("want default cpu request 300m, got %s", got)
+	}
+
+	if got := limits.DefaultRequest.Memory().String(); got != "200Mi" {
+		t.Fatalf("want default memory request 200Mi, got %s", got)
+	}
+
+	if limits.Default != nil {
+		t.Fatalf("want no default limits, got %v", limits.Default)
+	}
+
+	hard := proposal.ResourceQuota.Spec.Hard
+
+	// (200m + 200m + 300m + 1) * 1.5
+	if got := hard[corev1.ResourceRequestsCPU]; got.String() != "2550m" {
+		t.Fatalf("want requests.cpu 2550m, got %s", got.String())
+	}
+
+	if _, ok := hard[corev1.ResourceLimitsCPU]; ok {
+		t.Fatal("want no limits.cpu without container limits")
+	}
+}
+
+func TestProposeDefaultLimit(t *testing.T) {
+	t.Parallel()
+
+	pods := make([]*types.PodResources, 0)
+
+	// only small containers have limits, so median of limits is less than median of requests
+	for _, size := range []struct{ template, cpu, cpuLimit, memory, memoryLimit string }{
+		{"a-", "100m", "150m", "100Mi", "128Mi"},
+		{"b-", "1", "", "1Gi", ""},
+		{"c-", "2", "", "2Gi", ""},
+	} {
+		pods = append(pods, &types.PodResources{
+			Namespace:     "default",
+			PodTemplate:   size.template,
+			ContainerName: "app",
+			Phase:         "Running",
+			CPURequest:    size.cpu,
+			CPULimit:      size.cpuLimit,
+			MemoryRequest: size.memory,
+			MemoryLimit:   size.memoryLimit,
+		})
+	}
+
+	proposals := policy.Propose(pods, 0, func(_ *types.PodResources) bool { return true })
+
+	limits := proposals[0].LimitRange.Spec.Limits[0]
+
+	if limits.Default.Cpu().Cmp(*limits.DefaultRequest.Cpu()) < 0 {
+		t.Fatalf("default cpu limit %s is less than request %s", limits.Default.Cpu(), limits.DefaultRequest.Cpu())
+	}
+
+	if got := limits.Default.Memory().String(); got != "1Gi" {
+		t.Fatalf("want default memory limit 1Gi, got %s", got)
+	}
+}
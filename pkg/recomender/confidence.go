@@ -0,0 +1,83 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+// addConfidence adds number of samples and time span of metrics that was used for recomendation.
+func addConfidence(pod *types.PodResources, metricsExtra string, result *types.Recomendations) error {
+	retention, err := metrics.GetRetention()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	samplesQuery := fmt.Sprintf(`max(count_over_time(container_memory_working_set_bytes{container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)                                                                                                                                                                                                         //nolint:lll
+	spanQuery := fmt.Sprintf(`max(max_over_time(timestamp(container_memory_working_set_bytes{container="%s",namespace="%s"%s})[%s:1m])) - min(min_over_time(timestamp(container_memory_working_set_bytes{container="%s",namespace="%s"%s})[%s:1m]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention) //nolint:lll
+
+	samples, err := metrics.Query(samplesQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting samples")
+	}
+
+	span, err := metrics.Query(spanQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting data span")
+	}
+
+	if len(samples) == 1 {
+		result.Samples = int(samples[0].Value)
+	}
+
+	if len(span) == 1 {
+		result.DataSpan = time.Duration(float64(span[0].Value) * float64(time.Second))
+	}
+
+	result.Confidence = types.GetConfidence(result.Samples, result.DataSpan, retention)
+
+	if isExplain() {
+		result.Explain = append(result.Explain,
+			newExplanation("Samples", "", "count", samplesQuery, samples),
+			newExplanation("DataSpan", "", "last - first sample", spanQuery, span, "seconds converted to duration"),
+		)
+	}
+
+	return nil
+}
+
+// IsConfident returns true if recomendation can be used to change resources,
+// recomendations with low confidence are used only with -force.
+func IsConfident(pod *types.PodResources) bool {
+	if *config.Get().Force {
+		return true
+	}
+
+	recomendation := pod.GetRecomendation()
+	if recomendation == nil {
+		return false
+	}
+
+	minConfidence, err := types.ParseConfidence(*config.Get().MinConfidence)
+	if err != nil {
+		return false
+	}
+
+	return recomendation.Confidence.AtLeast(minConfidence)
+}
@@ -0,0 +1,113 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+)
+
+// AddOOMHistory adds OOMKilled events of container from prometheus for retention period,
+// event is an increase of restarts of container when last terminated reason is OOMKilled.
+func AddOOMHistory(pod *types.PodResources) error {
+	retention, err := metrics.GetRetention()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	metricsExtra := ""
+
+	if len(*config.Get().PrometheusGroupField) > 0 {
+		metricsExtra += fmt.Sprintf(`,%s=~"%s"`, *config.Get().PrometheusGroupField, *config.Get().PrometheusGroupValue)
+	}
+
+	selector := fmt.Sprintf(`container="%s",namespace="%s",pod="%s"%s`, pod.ContainerName, pod.Namespace, pod.PodName, metricsExtra) //nolint:lll
+
+	restartsQuery := fmt.Sprintf(`max(kube_pod_container_status_restarts_total{%s})`, selector)
+	reasonQuery := fmt.Sprintf(`max(kube_pod_container_status_last_terminated_reason{reason="OOMKilled",%s})`, selector)
+	limitQuery := fmt.Sprintf(`max(kube_pod_container_resource_limits{resource="memory",%s})`, selector)
+
+	end := time.Now()
+	start := end.Add(-retention)
+	step := GetRangeStep(retention)
+
+	restarts, err := queryRangeValues(restartsQuery, start, end, step)
+	if err != nil {
+		return errors.Wrap(err, "error getting restarts")
+	}
+
+	reasons, err := queryRangeValues(reasonQuery, start, end, step)
+	if err != nil {
+		return errors.Wrap(err, "error getting terminated reason")
+	}
+
+	limits, err := queryRangeValues(limitQuery, start, end, step)
+	if err != nil {
+		return errors.Wrap(err, "error getting memory limits")
+	}
+
+	for i := 1; i < len(restarts); i++ {
+		if restarts[i].Value <= restarts[i-1].Value {
+			continue
+		}
+
+		if reason, ok := findValue(reasons, restarts[i].Timestamp); !ok || reason == 0 {
+			continue
+		}
+
+		// container can be killed several times during step
+		event := types.OOMEvent{
+			Time:   restarts[i].Timestamp.Time(),
+			Source: types.OOMEventSourcePrometheus,
+			Count:  int(restarts[i].Value - restarts[i-1].Value),
+		}
+
+		if limit, ok := findValue(limits, restarts[i].Timestamp); ok && limit > 0 {
+			event.MemoryLimit = types.FormatResource(types.MemoryResourcePlaningType, float64(limit))
+		}
+
+		// restart is detected with precision of step, events from kubernetes are more accurate
+		pod.AddOOMEvent(event, 2*step) //nolint:gomnd
+	}
+
+	return nil
+}
+
+func queryRangeValues(query string, start, end time.Time, step time.Duration) ([]model.SamplePair, error) {
+	matrix, err := metrics.QueryRange(query, start, end, step)
+	if err != nil {
+		return nil, err //nolint:wrapcheck
+	}
+
+	if len(matrix) != 1 {
+		return nil, nil
+	}
+
+	return matrix[0].Values, nil
+}
+
+func findValue(values []model.SamplePair, timestamp model.Time) (model.SampleValue, bool) {
+	for _, value := range values {
+		if value.Timestamp.Equal(timestamp) {
+			return value.Value, true
+		}
+	}
+
+	return 0, false
+}
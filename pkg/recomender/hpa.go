@@ -0,0 +1,142 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// default tolerance of horizontal pod autoscaler, smaller changes of utilization do not change replicas.
+const hpaTolerance = 0.1
+
+// PodRequests are current and recomended requests of all containers of pod,
+// containers without recomendation keep current requests.
+type PodRequests struct {
+	CPU              float64
+	Memory           float64
+	RecomendedCPU    float64
+	RecomendedMemory float64
+}
+
+// GetPodRequests returns requests of pods by namespace and name, requests of pod are taken from pod spec,
+// so containers that are not in results keep current requests, init containers are not counted.
+func GetPodRequests(pods []*types.PodResources) map[string]*PodRequests {
+	result := make(map[string]*PodRequests)
+
+	for _, pod := range pods {
+		if pod.InitContainer {
+			continue
+		}
+
+		requests, ok := result[pod.GetPodNamespaceName()]
+		if !ok {
+			requests = &PodRequests{
+				CPU:              pod.PodCPURequest,
+				Memory:           pod.PodMemoryRequest,
+				RecomendedCPU:    pod.PodCPURequest,
+				RecomendedMemory: pod.PodMemoryRequest,
+			}
+
+			result[pod.GetPodNamespaceName()] = requests
+		}
+
+		recomendation := pod.GetRecomendation()
+		if recomendation == nil {
+			continue
+		}
+
+		if len(recomendation.CPURequest) > 0 {
+			requests.RecomendedCPU += utils.QuantityToFloat(recomendation.CPURequest) - utils.QuantityToFloat(pod.CPURequest)
+		}
+
+		if len(recomendation.MemoryRequest) > 0 {
+			requests.RecomendedMemory += utils.QuantityToFloat(recomendation.MemoryRequest) - utils.QuantityToFloat(pod.MemoryRequest) //nolint:lll
+		}
+	}
+
+	return result
+}
+
+// SetHPAEffect calculates how recomended requests change replicas count of horizontal pod autoscaler,
+// utilization is usage divided by request, so smaller request gives more replicas with the same target.
+// Utilization of pod metrics is calculated with requests of all containers of pod.
+func SetHPAEffect(pod *types.PodResources, podRequests *PodRequests) {
+	recomendation := pod.GetRecomendation()
+
+	// recomendation is shared by pods of workload and calculated once
+	if recomendation == nil || pod.HPA == nil || recomendation.HPA != nil {
+		return
+	}
+
+	effect := types.HPAEffect{}
+
+	cpu := utils.QuantityToFloat(pod.CPURequest)
+	recomendedCPU := utils.QuantityToFloat(recomendation.CPURequest)
+	memory := utils.QuantityToFloat(pod.MemoryRequest)
+	recomendedMemory := utils.QuantityToFloat(recomendation.MemoryRequest)
+
+	if podRequests != nil {
+		if pod.HPA.PodCPU {
+			cpu, recomendedCPU = podRequests.CPU, podRequests.RecomendedCPU
+		}
+
+		if pod.HPA.PodMemory {
+			memory, recomendedMemory = podRequests.Memory, podRequests.RecomendedMemory
+		}
+	}
+
+	cpuFactor, cpuChanged := requestFactor(pod.HPA.TargetCPU, cpu, recomendedCPU)
+	memoryFactor, memoryChanged := requestFactor(pod.HPA.TargetMemory, memory, recomendedMemory)
+
+	// request of container is not changed, so it does not change pod utilization
+	cpuChanged = cpuChanged && len(recomendation.CPURequest) > 0
+	memoryChanged = memoryChanged && len(recomendation.MemoryRequest) > 0
+
+	// autoscaler uses maximum of replicas proposed by every metric
+	effect.ReplicasChange = math.Max(cpuFactor, memoryFactor)
+
+	if *config.Get().HPAKeepReplicas && (cpuChanged || memoryChanged) {
+		effect.KeepRequests = true
+
+		if cpuChanged {
+			effect.SuggestedTargetCPU = int32(math.Round(float64(pod.HPA.TargetCPU) * cpuFactor))
+			recomendation.CPURequest = pod.CPURequest
+			recomendation.AddExplainAdjustment("CPURequest", fmt.Sprintf("kept to keep replicas of %s, replicas x%.1f with recomended request", pod.HPA.Name, cpuFactor)) //nolint:lll
+		}
+
+		if memoryChanged {
+			effect.SuggestedTargetMemory = int32(math.Round(float64(pod.HPA.TargetMemory) * memoryFactor))
+			recomendation.MemoryRequest = pod.MemoryRequest
+			recomendation.AddExplainAdjustment("MemoryRequest", fmt.Sprintf("kept to keep replicas of %s, replicas x%.1f with recomended request", pod.HPA.Name, memoryFactor)) //nolint:lll
+		}
+	}
+
+	recomendation.HPA = &effect
+}
+
+// requestFactor returns expected change of replicas when request of resource scaled by autoscaler is changed,
+// returns true if change is bigger than autoscaler tolerance.
+func requestFactor(target int32, currentRequest, recomendedRequest float64) (float64, bool) {
+	if target == 0 || currentRequest == 0 || recomendedRequest == 0 {
+		return 0, false
+	}
+
+	factor := currentRequest / recomendedRequest
+
+	return factor, math.Abs(factor-1) > hpaTolerance
+}
@@ -0,0 +1,189 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender_test
+
+import (
+	"math"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestGetPodRequests(t *testing.T) {
+	t.Parallel()
+
+	app := &types.PodResources{
+		Namespace:        "test",
+		PodName:          "app-1",
+		ContainerName:    "app",
+		CPURequest:       "500m",
+		MemoryRequest:    "512Mi",
+		PodCPURequest:    1.5,
+		PodMemoryRequest: 1024 * 1024 * 1024,
+	}
+
+	app.SetRecomendation(&types.Recomendations{CPURequest: "250m"})
+
+	// init container and sidecar without recomendation do not change recomended requests
+	initContainer := &types.PodResources{
+		Namespace:     "test",
+		PodName:       "app-1",
+		ContainerName: "init",
+		CPURequest:    "2",
+		InitContainer: true,
+	}
+
+	initContainer.SetRecomendation(&types.Recomendations{CPURequest: "100m"})
+
+	sidecar := &types.PodResources{
+		Namespace:        "test",
+		PodName:          "app-1",
+		ContainerName:    "sidecar",
+		CPURequest:       "1",
+		PodCPURequest:    1.5,
+		PodMemoryRequest: 1024 * 1024 * 1024,
+	}
+
+	result := recomender.GetPodRequests([]*types.PodResources{app, initContainer, sidecar})
+
+	requests, ok := result["test/app-1"]
+	if !ok || len(result) != 1 {
+		t.Fatalf("want requests of test/app-1, got %v", result)
+	}
+
+	if requests.CPU != 1.5 || requests.RecomendedCPU != 1.25 {
+		t.Fatalf("want 1.5 cpu and 1.25 recomended cpu, got %f and %f", requests.CPU, requests.RecomendedCPU)
+	}
+
+	if requests.Memory != 1024*1024*1024 || requests.RecomendedMemory != requests.Memory {
+		t.Fatalf("want 1Gi memory without change, got %f and %f", requests.Memory, requests.RecomendedMemory)
+	}
+}
+
+func TestSetHPAEffect(t *testing.T) { //nolint:paralleltest,tparallel // changes -hpa.keepReplicas
+	type test struct {
+		keepReplicas   bool
+		hpa            types.HPA
+		cpuRequest     string
+		recomendation  *types.Recomendations
+		podRequests    *recomender.PodRequests
+		replicasChange float64
+		keepRequests   bool
+		targetCPU      int32
+		wantCPURequest string
+	}
+
+	tests := map[string]test{
+		"container metric": {
+			keepReplicas:   true,
+			hpa:            types.HPA{TargetCPU: 50},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{CPURequest: "500m"},
+			podRequests:    &recomender.PodRequests{CPU: 2, RecomendedCPU: 1.5},
+			replicasChange: 2,
+			keepRequests:   true,
+			targetCPU:      100,
+			wantCPURequest: "1",
+		},
+		"pod metric": {
+			keepReplicas:   true,
+			hpa:            types.HPA{TargetCPU: 50, PodCPU: true},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{CPURequest: "500m"},
+			podRequests:    &recomender.PodRequests{CPU: 2, RecomendedCPU: 1.5},
+			replicasChange: 2.0 / 1.5,
+			keepRequests:   true,
+			targetCPU:      67,
+			wantCPURequest: "1",
+		},
+		"below tolerance": {
+			keepReplicas:   true,
+			hpa:            types.HPA{TargetCPU: 50},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{CPURequest: "950m"},
+			replicasChange: 1 / 0.95,
+			wantCPURequest: "950m",
+		},
+		"above tolerance": {
+			keepReplicas:   true,
+			hpa:            types.HPA{TargetCPU: 50},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{CPURequest: "800m"},
+			replicasChange: 1.25,
+			keepRequests:   true,
+			targetCPU:      63,
+			wantCPURequest: "1",
+		},
+		"keepReplicas disabled": {
+			keepReplicas:   false,
+			hpa:            types.HPA{TargetCPU: 50},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{CPURequest: "500m"},
+			replicasChange: 2,
+			wantCPURequest: "500m",
+		},
+		"container without cpu recomendation": {
+			keepReplicas:   true,
+			hpa:            types.HPA{TargetCPU: 50, PodCPU: true},
+			cpuRequest:     "1",
+			recomendation:  &types.Recomendations{MemoryRequest: "100Mi"},
+			podRequests:    &recomender.PodRequests{CPU: 2, RecomendedCPU: 1},
+			replicasChange: 2,
+			wantCPURequest: "",
+		},
+	}
+
+	defer func() { *config.Get().HPAKeepReplicas = true }()
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			*config.Get().HPAKeepReplicas = tc.keepReplicas
+
+			hpa := tc.hpa
+			pod := &types.PodResources{CPURequest: tc.cpuRequest, HPA: &hpa}
+
+			pod.SetRecomendation(tc.recomendation)
+
+			recomender.SetHPAEffect(pod, tc.podRequests)
+
+			effect := tc.recomendation.HPA
+			if effect == nil {
+				t.Fatal("want effect of autoscaler")
+			}
+
+			if math.Abs(effect.ReplicasChange-tc.replicasChange) > 0.0001 {
+				t.Fatalf("want replicas x%f, got x%f", tc.replicasChange, effect.ReplicasChange)
+			}
+
+			if effect.KeepRequests != tc.keepRequests || effect.SuggestedTargetCPU != tc.targetCPU {
+				t.Fatalf("want keep %v with target %d, got %v with %d", tc.keepRequests, tc.targetCPU, effect.KeepRequests, effect.SuggestedTargetCPU) //nolint:lll
+			}
+
+			if tc.recomendation.CPURequest != tc.wantCPURequest {
+				t.Fatalf("want cpu request %q, got %q", tc.wantCPURequest, tc.recomendation.CPURequest)
+			}
+		})
+	}
+
+	t.Run("without recomendation", func(t *testing.T) {
+		pod := &types.PodResources{CPURequest: "1", HPA: &types.HPA{TargetCPU: 50}}
+
+		recomender.SetHPAEffect(pod, nil)
+
+		if pod.GetRecomendation() != nil {
+			t.Fatal("want no recomendation")
+		}
+	})
+}
@@ -0,0 +1,93 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+)
+
+//nolint:gochecknoglobals
+var imageVersionsCache = make(map[string][]types.ImageVersion)
+
+// SetImageVersions adds median usage of all pods of every image of container during retention,
+// image of pod is taken from kube_pod_container_info.
+func SetImageVersions(pod *types.PodResources) error {
+	cacheKey, metricsExtra, err := getSelector(pod)
+	if err != nil {
+		return err
+	}
+
+	if versions, ok := imageVersionsCache[cacheKey]; ok {
+		pod.ImageVersions = versions
+
+		return nil
+	}
+
+	retention := *config.Get().PrometheusRetention
+	info := fmt.Sprintf(`max by (namespace,pod,container,image) (kube_pod_container_info{container="%s",namespace="%s"%s})`, pod.ContainerName, pod.Namespace, metricsExtra) //nolint:lll
+
+	// median of all pods with image at every step, then median during retention
+	memoryQuery := fmt.Sprintf(`quantile_over_time(0.50,(quantile by (image) (0.50, container_memory_working_set_bytes{container="%s",namespace="%s"%s} * on(namespace,pod,container) group_left(image) %s))[%s:1m])`, pod.ContainerName, pod.Namespace, metricsExtra, info, retention)       //nolint:lll
+	cpuQuery := fmt.Sprintf(`quantile_over_time(0.50,(quantile by (image) (0.50, rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[1m]) * on(namespace,pod,container) group_left(image) %s))[%s:1m])`, pod.ContainerName, pod.Namespace, metricsExtra, info, retention) //nolint:lll
+	lastSeenQuery := fmt.Sprintf(`max by (image) (max_over_time(timestamp(%s)[%s:1m]))`, info, retention)
+
+	memory, err := metrics.Query(memoryQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting memory usage by image")
+	}
+
+	cpu, err := metrics.Query(cpuQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting cpu usage by image")
+	}
+
+	lastSeen, err := metrics.Query(lastSeenQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting last seen of image")
+	}
+
+	versions := make([]types.ImageVersion, 0, len(lastSeen))
+
+	for _, sample := range lastSeen {
+		image := string(sample.Metric["image"])
+
+		versions = append(versions, types.ImageVersion{
+			Image:       image,
+			LastSeen:    time.Unix(int64(sample.Value), 0),
+			MemoryUsage: imageValue(memory, image),
+			CPUUsage:    imageValue(cpu, image),
+		})
+	}
+
+	imageVersionsCache[cacheKey] = versions
+	pod.ImageVersions = versions
+
+	return nil
+}
+
+func imageValue(vector model.Vector, image string) float64 {
+	for _, sample := range vector {
+		if string(sample.Metric["image"]) == image {
+			return float64(sample.Value)
+		}
+	}
+
+	return 0
+}
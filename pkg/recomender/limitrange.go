@@ -0,0 +1,78 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+// CheckLimitRange flags recomendations that would be rejected by LimitRange of namespace,
+// with -limitRange.clamp recomendations are clamped to LimitRange min, max and maxLimitRequestRatio.
+func CheckLimitRange(pod *types.PodResources) {
+	recomendation := pod.GetRecomendation()
+
+	// recomendation is shared by pods of workload and checked once
+	if recomendation == nil || pod.LimitRange == nil || recomendation.LimitRangeViolations != nil {
+		return
+	}
+
+	recomendation.LimitRangeViolations = make([]string, 0)
+
+	report := func(resourceName string, value *string, clamped, violation string) {
+		if len(violation) == 0 {
+			return
+		}
+
+		recomendation.LimitRangeViolations = append(recomendation.LimitRangeViolations, resourceName+" "+violation)
+
+		if *config.Get().LimitRangeClamp {
+			*value = clamped
+			recomendation.AddExplainAdjustment(resourceName, "clamped to LimitRange "+pod.LimitRange.Name+", "+violation)
+		}
+	}
+
+	check := func(resourceName string, planingType types.ResourcePlaningType, value *string) {
+		clamped, violation := pod.LimitRange.Check(planingType, *value)
+
+		report(resourceName, value, clamped, violation)
+	}
+
+	// current request or limit is used when only one of them is recomended
+	checkRatio := func(resourceName string, planingType types.ResourcePlaningType, currentRequest, currentLimit string, request, limit *string) { //nolint:lll
+		if len(*request) == 0 && len(*limit) == 0 {
+			return
+		}
+
+		requestValue, limitValue := *request, *limit
+
+		if len(requestValue) == 0 {
+			requestValue = currentRequest
+		}
+
+		if len(limitValue) == 0 {
+			limitValue = currentLimit
+		}
+
+		clamped, violation := pod.LimitRange.CheckRatio(planingType, requestValue, limitValue)
+
+		report(resourceName, limit, clamped, violation)
+	}
+
+	check("MemoryRequest", types.MemoryResourcePlaningType, &recomendation.MemoryRequest)
+	check("MemoryLimit", types.MemoryResourcePlaningType, &recomendation.MemoryLimit)
+	check("CPURequest", types.CPUResourcePlaningType, &recomendation.CPURequest)
+	check("CPULimit", types.CPUResourcePlaningType, &recomendation.CPULimit)
+	checkRatio("MemoryLimit", types.MemoryResourcePlaningType, pod.MemoryRequest, pod.MemoryLimit, &recomendation.MemoryRequest, &recomendation.MemoryLimit) //nolint:lll
+	checkRatio("CPULimit", types.CPUResourcePlaningType, pod.CPURequest, pod.CPULimit, &recomendation.CPURequest, &recomendation.CPULimit)                   //nolint:lll
+}
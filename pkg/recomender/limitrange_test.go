@@ -0,0 +1,107 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/recomender"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestCheckLimitRange(t *testing.T) { //nolint:paralleltest // changes -limitRange.clamp
+	tests := []struct {
+		clamp      bool
+		want       string
+		violations string
+	}{
+		{
+			clamp:      false,
+			want:       "32Mi 512Mi 100m 3",
+			violations: "MemoryRequest 32Mi < min 64Mi;CPULimit 3 > max 2;MemoryLimit 512Mi/32Mi > maxLimitRequestRatio 2;CPULimit 3/100m > maxLimitRequestRatio 2", //nolint:lll
+		},
+		{
+			clamp:      true,
+			want:       "64Mi 128Mi 100m 200m",
+			violations: "MemoryRequest 32Mi < min 64Mi;CPULimit 3 > max 2;MemoryLimit 512Mi/64Mi > maxLimitRequestRatio 2;CPULimit 2/100m > maxLimitRequestRatio 2", //nolint:lll
+		},
+	}
+
+	defer func() { *config.Get().LimitRangeClamp = false }()
+
+	for _, test := range tests {
+		*config.Get().LimitRangeClamp = test.clamp
+
+		recomendation := &types.Recomendations{MemoryRequest: "32Mi", MemoryLimit: "512Mi", CPURequest: "100m", CPULimit: "3"}
+
+		pod := &types.PodResources{
+			MemoryRequest: "256Mi",
+			CPURequest:    "1",
+			LimitRange: &types.LimitRange{
+				Name:           "limits",
+				MinMemory:      "64Mi",
+				MaxCPU:         "2",
+				MaxMemoryRatio: 2,
+				MaxCPURatio:    2,
+			},
+		}
+
+		pod.SetRecomendation(recomendation)
+
+		recomender.CheckLimitRange(pod)
+
+		got := strings.Join([]string{
+			recomendation.MemoryRequest,
+			recomendation.MemoryLimit,
+			recomendation.CPURequest,
+			recomendation.CPULimit,
+		}, " ")
+
+		if got != test.want {
+			t.Fatalf("clamp %v: want %s, got %s", test.clamp, test.want, got)
+		}
+
+		if violations := strings.Join(recomendation.LimitRangeViolations, ";"); violations != test.violations {
+			t.Fatalf("clamp %v: want %s, got %s", test.clamp, test.violations, violations)
+		}
+	}
+}
+
+func TestCheckLimitRangeCurrentLimit(t *testing.T) {
+	t.Parallel()
+
+	// only request is recomended, current limit is checked with recomended request
+	recomendation := &types.Recomendations{MemoryRequest: "100Mi"}
+
+	pod := &types.PodResources{
+		MemoryRequest: "256Mi",
+		MemoryLimit:   "512Mi",
+		LimitRange:    &types.LimitRange{MaxMemoryRatio: 2},
+	}
+
+	pod.SetRecomendation(recomendation)
+
+	recomender.CheckLimitRange(pod)
+
+	want := "MemoryLimit 512Mi/100Mi > maxLimitRequestRatio 2"
+
+	if violations := strings.Join(recomendation.LimitRangeViolations, ";"); violations != want {
+		t.Fatalf("want %s, got %s", want, violations)
+	}
+
+	if pod.MemoryLimit != "512Mi" {
+		t.Fatalf("want current limit unchanged, got %s", pod.MemoryLimit)
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+)
+
+const (
+	// number of points in range queries.
+	rangePoints = 500
+	minStep     = time.Minute
+	// containers that will reach limit in this number of horizons have medium risk.
+	mediumRiskHorizons = 3
+)
+
+// GetRangeStep returns step of range query for retention period.
+func GetRangeStep(retention time.Duration) time.Duration {
+	step := retention / rangePoints
+
+	if step < minStep {
+		return minStep
+	}
+
+	return step.Truncate(time.Second)
+}
+
+// SetOOMRisk estimates when memory usage of container reaches memory limit with linear trend of memory usage.
+func SetOOMRisk(pod *types.PodResources) error {
+	horizon, err := model.ParseDuration(*config.Get().OOMRiskHorizon)
+	if err != nil {
+		return errors.Wrap(err, "error parsing horizon")
+	}
+
+	memoryLimit := utils.QuantityToFloat(pod.MemoryLimit)
+
+	// container without memory limit can not be killed by limit
+	if memoryLimit == 0 {
+		return nil
+	}
+
+	retention, err := metrics.GetRetention()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	metricsExtra := ""
+
+	if len(*config.Get().PrometheusGroupField) > 0 {
+		metricsExtra += fmt.Sprintf(`,%s=~"%s"`, *config.Get().PrometheusGroupField, *config.Get().PrometheusGroupValue)
+	}
+
+	query := fmt.Sprintf(`max(container_memory_working_set_bytes{container="%s",namespace="%s",pod="%s"%s})`, pod.ContainerName, pod.Namespace, pod.PodName, metricsExtra) //nolint:lll
+
+	end := time.Now()
+
+	matrix, err := metrics.QueryRange(query, end.Add(-retention), end, GetRangeStep(retention))
+	if err != nil {
+		return errors.Wrap(err, "error getting memory trend")
+	}
+
+	if len(matrix) != 1 || len(matrix[0].Values) == 0 {
+		return nil
+	}
+
+	xs := make([]float64, 0, len(matrix[0].Values))
+	ys := make([]float64, 0, len(matrix[0].Values))
+
+	// seconds since first sample, unix timestamps lose precision in regression
+	firstTimestamp := matrix[0].Values[0].Timestamp.Unix()
+
+	for _, value := range matrix[0].Values {
+		xs = append(xs, float64(value.Timestamp.Unix()-firstTimestamp))
+		ys = append(ys, float64(value.Value))
+	}
+
+	slope, intercept, ok := utils.LinearRegression(xs, ys)
+	if !ok {
+		return nil
+	}
+
+	lastTimestamp := xs[len(xs)-1]
+
+	pod.MemoryGrowth = types.FormatResource(types.MemoryResourcePlaningType, slope*time.Hour.Seconds())
+
+	// memory usage is not growing
+	if slope <= 0 {
+		pod.OOMRisk = types.OOMRiskLow
+
+		return nil
+	}
+
+	timeToLimit := time.Duration((memoryLimit - (intercept + slope*lastTimestamp)) / slope * float64(time.Second))
+	if timeToLimit < 0 {
+		timeToLimit = 0
+	}
+
+	pod.HoursToMemoryLimit = fmt.Sprintf("%.1f", timeToLimit.Hours())
+
+	switch {
+	case timeToLimit <= time.Duration(horizon):
+		pod.OOMRisk = types.OOMRiskHigh
+	case timeToLimit <= mediumRiskHorizons*time.Duration(horizon):
+		pod.OOMRisk = types.OOMRiskMedium
+	default:
+		pod.OOMRisk = types.OOMRiskLow
+	}
+
+	return nil
+}
@@ -13,18 +13,14 @@ limitations under the License.
 package recomender
 
 import (
-	"context"
 	"fmt"
 	"strings"
-	"time"
 
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
 	"github.com/pkg/errors"
-	"github.com/prometheus/client_golang/api"
-	v1 "github.com/prometheus/client_golang/api/prometheus/v1"
-	promConfig "github.com/prometheus/common/config"
 	"github.com/prometheus/common/model"
 	log "github.com/sirupsen/logrus"
 )
@@ -32,15 +28,19 @@ import (
 //nolint:gochecknoglobals
 var recomendationCache = make(map[string]*types.Recomendations)
 
-func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funlen,cyclop
-	limitsStrategy, err := types.ParseStrategyType(*config.Get().Strategy)
-	if err != nil {
-		return nil, errors.Wrap(err, "error parsing strategy")
-	}
+// ForceExplain collects explain of recomendations without -explain, for example for details pane of tui.
+var ForceExplain = false //nolint:gochecknoglobals
 
+func isExplain() bool {
+	return *config.Get().Explain || ForceExplain
+}
+
+// getSelector returns cache key and extra prometheus labels of container metrics
+// for pod or pod template depends on groupby.
+func getSelector(pod *types.PodResources) (string, string, error) {
 	groupBy, err := types.ParseGroupBy(*config.Get().GroupBy)
 	if err != nil {
-		return nil, errors.Wrap(err, "error parsing collector type")
+		return "", "", errors.Wrap(err, "error parsing collector type")
 	}
 
 	cacheKey := fmt.Sprintf("%s:%s", pod.ContainerName, pod.Namespace)
@@ -72,6 +72,24 @@ func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funl
 		metricsExtra += fmt.Sprintf(`,pod="%s"`, pod.PodName)
 	}
 
+	return cacheKey, metricsExtra, nil
+}
+
+func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funlen,cyclop
+	strategies, err := types.ParseStrategies(*config.Get().Strategy, config.Get().CustomStrategies)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing strategy")
+	}
+
+	cacheKey, metricsExtra, err := getSelector(pod)
+	if err != nil {
+		return nil, err
+	}
+
+	if *config.Get().ImageSplit {
+		cacheKey += ":" + pod.Image
+	}
+
 	// check for recomendation in cache
 	if _, ok := recomendationCache[cacheKey]; ok {
 		log.Debugf("recomendation found in cache key=%s", cacheKey)
@@ -80,47 +98,33 @@ func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funl
 	}
 
 	// requests = 50 percentile of resource usage
-	// limits (conservate) = max resource usage
-	// limits (aggressive) = 99 percentile of resource usage
-	memoryRequestQuery := fmt.Sprintf(`max(quantile_over_time(0.50,container_memory_working_set_bytes{container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)                 //nolint:lll
-	memoryLimitQueryAggresive := fmt.Sprintf(`max(quantile_over_time(0.99,container_memory_working_set_bytes{container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)          //nolint:lll
-	memoryLimitQueryConservate := fmt.Sprintf(`max(max_over_time(container_memory_working_set_bytes{container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)                   //nolint:lll
-	cpuRequestQuery := fmt.Sprintf(`max(quantile_over_time(0.50,rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[1m])[%s:1m]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)        //nolint:lll
-	cpuLimitQueryAggresive := fmt.Sprintf(`max(quantile_over_time(0.99,rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[1m])[%s:1m]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention) //nolint:lll
-	cpuLimitQueryConservate := fmt.Sprintf(`max(max_over_time(rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[1m])[%s:1m]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)          //nolint:lll
-	oomkilled := fmt.Sprintf(`max(sum_over_time(kube_pod_container_status_last_terminated_reason{reason="OOMKilled",container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention)   //nolint:lll
-
-	memoryRequest, err := getMetrics(memoryRequestQuery)
+	// limits = quantile of resource usage from strategy, conservative strategy uses max resource usage
+	memoryUsage := fmt.Sprintf(`container_memory_working_set_bytes{container="%s",namespace="%s"%s}`, pod.ContainerName, pod.Namespace, metricsExtra)                                                                                           //nolint:lll
+	cpuUsage := fmt.Sprintf(`rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[1m])`, pod.ContainerName, pod.Namespace, metricsExtra)                                                                                     //nolint:lll
+	oomkilled := fmt.Sprintf(`max(sum_over_time(kube_pod_container_status_last_terminated_reason{reason="OOMKilled",container="%s",namespace="%s"%s}[%s]))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention) //nolint:lll
+
+	filter, err := usageFilter(pod, metricsExtra)
 	if err != nil {
-		return nil, errors.Wrap(err, "error getting memory request")
+		return nil, err
 	}
 
-	memoryLimitQuery := memoryLimitQueryConservate
-	if limitsStrategy == types.StrategyTypeAggressive {
-		memoryLimitQuery = memoryLimitQueryAggresive
-	}
+	memoryUsageRange := usageRange(memoryUsage, filter, false)
+	cpuUsageRange := usageRange(cpuUsage, filter, true)
 
-	memoryLimit, err := getMetrics(memoryLimitQuery)
-	if err != nil {
-		return nil, errors.Wrap(err, "error getting memory limits")
-	}
+	memoryRequestQuery := fmt.Sprintf(`max(quantile_over_time(0.50,%s))`, memoryUsageRange)
+	cpuRequestQuery := fmt.Sprintf(`max(quantile_over_time(0.50,%s))`, cpuUsageRange)
 
-	cpuRequest, err := getMetrics(cpuRequestQuery)
+	memoryRequest, err := metrics.Query(memoryRequestQuery)
 	if err != nil {
-		return nil, errors.Wrap(err, "error getting cpu request")
-	}
-
-	cpuLimitQuery := cpuLimitQueryConservate
-	if limitsStrategy == types.StrategyTypeAggressive {
-		cpuLimitQuery = cpuLimitQueryAggresive
+		return nil, errors.Wrap(err, "error getting memory request")
 	}
 
-	cpuLimit, err := getMetrics(cpuLimitQuery)
+	cpuRequest, err := metrics.Query(cpuRequestQuery)
 	if err != nil {
-		return nil, errors.Wrap(err, "error getting cpu limits")
+		return nil, errors.Wrap(err, "error getting cpu request")
 	}
 
-	containerOOMKilled, err := getMetrics(oomkilled)
+	containerOOMKilled, err := metrics.Query(oomkilled)
 	if err != nil {
 		return nil, errors.Wrap(err, "error getting OOMKilled")
 	}
@@ -131,20 +135,8 @@ func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funl
 		result.MemoryRequest = utils.ByteCountSI(int64(memoryRequest[0].Value))
 	}
 
-	if len(memoryLimit) == 1 {
-		result.MemoryLimit = utils.ByteCountSI(int64(memoryLimit[0].Value))
-	}
-
 	if len(cpuRequest) == 1 {
-		b := fmt.Sprintf("%.0fm", cpuRequest[0].Value*utils.BytesUnit)
-
-		result.CPURequest = strings.ReplaceAll(b, ".00", "")
-	}
-
-	if len(cpuLimit) == 1 {
-		b := fmt.Sprintf("%.0fm", cpuLimit[0].Value*utils.BytesUnit)
-
-		result.CPULimit = strings.ReplaceAll(b, ".00", "")
+		result.CPURequest = formatCPU(float64(cpuRequest[0].Value))
 	}
 
 	if len(containerOOMKilled) == 1 {
@@ -153,49 +145,97 @@ func Get(pod *types.PodResources) (*types.Recomendations, error) { //nolint:funl
 		}
 	}
 
-	// add result in cache
-	recomendationCache[cacheKey] = &result
+	const (
+		requestFunction = "quantile 0.50"
+		memoryRounding  = "bytes formatted with SI unit"
+		cpuRounding     = "cores converted to millicores and rounded"
+	)
 
-	return &result, nil
-}
+	if isExplain() {
+		result.Explain = []types.Explanation{
+			newExplanation("MemoryRequest", "", requestFunction, memoryRequestQuery, memoryRequest, memoryRounding),
+			newExplanation("CPURequest", "", requestFunction, cpuRequestQuery, cpuRequest, cpuRounding),
+			newExplanation("OOMKilled", "", "max", oomkilled, containerOOMKilled),
+		}
+	}
 
-func getMetrics(query string) (model.Vector, error) {
-	log.Debugf("query: %s", query)
+	// requests are shared between strategies, only limits are queried for every strategy
+	for i, strategy := range strategies {
+		memoryLimitQuery := fmt.Sprintf(`max(%s)`, strategy.Function(memoryUsageRange))
+		cpuLimitQuery := fmt.Sprintf(`max(%s)`, strategy.Function(cpuUsageRange))
 
-	prometheusConfig := api.Config{
-		Address: *config.Get().PrometheusURL,
-	}
+		memoryLimit, err := metrics.Query(memoryLimitQuery)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting memory limits")
+		}
+
+		cpuLimit, err := metrics.Query(cpuLimitQuery)
+		if err != nil {
+			return nil, errors.Wrap(err, "error getting cpu limits")
+		}
+
+		limits := types.StrategyLimits{Strategy: strategy.Name}
+
+		if len(memoryLimit) == 1 {
+			limits.MemoryLimit = utils.ByteCountSI(int64(memoryLimit[0].Value))
+		}
+
+		if len(cpuLimit) == 1 {
+			limits.CPULimit = formatCPU(float64(cpuLimit[0].Value))
+		}
 
-	if len(*config.Get().PrometheusUser) > 0 {
-		prometheusConfig.RoundTripper = promConfig.NewBasicAuthRoundTripper(
-			*config.Get().PrometheusUser,
-			promConfig.Secret(*config.Get().PrometheusPassword),
-			"",
-			"",
-			api.DefaultRoundTripper,
-		)
+		if i == 0 {
+			result.MemoryLimit = limits.MemoryLimit
+			result.CPULimit = limits.CPULimit
+		} else {
+			result.Strategies = append(result.Strategies, limits)
+		}
+
+		if isExplain() {
+			result.Explain = append(result.Explain,
+				newExplanation(types.LimitResourceName("MemoryLimit", i, strategy.Name), strategy.Name, strategy.FunctionName(), memoryLimitQuery, memoryLimit, memoryRounding), //nolint:lll
+				newExplanation(types.LimitResourceName("CPULimit", i, strategy.Name), strategy.Name, strategy.FunctionName(), cpuLimitQuery, cpuLimit, cpuRounding),             //nolint:lll
+			)
+		}
 	}
 
-	client, err := api.NewClient(prometheusConfig)
-	if err != nil {
-		return nil, errors.Wrap(err, "error creating client")
+	if err := addCPUThrottling(pod, metricsExtra, &result); err != nil {
+		return nil, err
 	}
 
-	v1api := v1.NewAPI(client)
+	if err := addStartupLimits(pod, metricsExtra, memoryUsage, cpuUsage, &result); err != nil {
+		return nil, err
+	}
 
-	result, warnings, err := v1api.Query(context.Background(), query, time.Now())
-	if err != nil {
-		return nil, errors.Wrap(err, "error creating client")
+	if err := addConfidence(pod, metricsExtra, &result); err != nil {
+		return nil, err
 	}
 
-	if len(warnings) > 0 {
-		log.Warn(warnings)
+	// add result in cache
+	recomendationCache[cacheKey] = &result
+
+	return &result, nil
+}
+
+func formatCPU(cores float64) string {
+	b := fmt.Sprintf("%.0fm", cores*utils.BytesUnit)
+
+	return strings.ReplaceAll(b, ".00", "")
+}
+
+func newExplanation(resource, strategy, function, query string, value model.Vector, adjustments ...string) types.Explanation { //nolint:lll
+	explanation := types.Explanation{
+		Resource:    resource,
+		Strategy:    strategy,
+		Function:    function,
+		Query:       query,
+		Value:       "no data",
+		Adjustments: adjustments,
 	}
 
-	v, ok := result.(model.Vector)
-	if !ok {
-		return nil, errors.New("assertion error")
+	if len(value) == 1 {
+		explanation.Value = value[0].Value.String()
 	}
 
-	return v, nil
+	return explanation
 }
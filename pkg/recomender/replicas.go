@@ -0,0 +1,60 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/replicas"
+	"github.com/pkg/errors"
+)
+
+// SetWorkloadUsage adds sum of cpu usage of all replicas of workload during retention.
+func SetWorkloadUsage(workload *replicas.Workload) error {
+	retention, err := metrics.GetRetention()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	metricsExtra := ""
+
+	if len(*config.Get().PrometheusGroupField) > 0 {
+		metricsExtra += fmt.Sprintf(`,%s=~"%s"`, *config.Get().PrometheusGroupField, *config.Get().PrometheusGroupValue)
+	}
+
+	// pods of deployment are selected by owner of their replicasets,
+	// pod name prefix also matches other deployments like app-worker
+	owners := fmt.Sprintf(`max by (namespace,pod) (label_replace(kube_pod_owner{namespace="%s",owner_kind="ReplicaSet"}, "replicaset", "$1", "owner_name", "(.+)") * on(namespace,replicaset) group_left() max by (namespace,replicaset) (kube_replicaset_owner{namespace="%s",owner_kind="Deployment",owner_name="%s"}))`, workload.Namespace, workload.Namespace, workload.Deployment) //nolint:lll
+
+	usage := fmt.Sprintf(`rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[5m])`, workload.ContainerName, workload.Namespace, metricsExtra) //nolint:lll
+
+	query := fmt.Sprintf(`sum(%s * on(namespace,pod) group_left() %s)`, usage, owners)
+
+	end := time.Now()
+
+	values, err := queryRangeValues(query, end.Add(-retention), end, GetRangeStep(retention))
+	if err != nil {
+		return errors.Wrap(err, "error getting workload usage")
+	}
+
+	workload.Usage = make([]float64, 0, len(values))
+
+	for _, value := range values {
+		workload.Usage = append(workload.Usage, float64(value.Value))
+	}
+
+	return nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/seasonality"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/pkg/errors"
+)
+
+//nolint:gochecknoglobals
+var seasonalityCache = make(map[string]*types.Seasonality)
+
+// step of seasonality range queries must be less than hour to build hourly profile.
+const maxSeasonalityStep = time.Hour
+
+// SetSeasonality analyzes cpu usage of workload by hour of day and weekday.
+func SetSeasonality(pod *types.PodResources) error {
+	cacheKey, metricsExtra, err := getSelector(pod)
+	if err != nil {
+		return err
+	}
+
+	if result, ok := seasonalityCache[cacheKey]; ok {
+		pod.Seasonality = result
+
+		return nil
+	}
+
+	location, err := time.LoadLocation(*config.Get().SeasonalityTimezone)
+	if err != nil {
+		return errors.Wrap(err, "error loading timezone")
+	}
+
+	retention, err := metrics.GetRetention()
+	if err != nil {
+		return err //nolint:wrapcheck
+	}
+
+	step := GetRangeStep(retention)
+	if step > maxSeasonalityStep {
+		step = maxSeasonalityStep
+	}
+
+	// usage of one pod of workload
+	cpuQuery := fmt.Sprintf(`avg(rate(container_cpu_usage_seconds_total{container="%s",namespace="%s"%s}[5m]))`, pod.ContainerName, pod.Namespace, metricsExtra) //nolint:lll
+	memoryQuery := fmt.Sprintf(`avg(container_memory_working_set_bytes{container="%s",namespace="%s"%s})`, pod.ContainerName, pod.Namespace, metricsExtra)       //nolint:lll
+
+	end := time.Now()
+
+	cpuSamples, err := querySamples(cpuQuery, end.Add(-retention), end, step)
+	if err != nil {
+		return errors.Wrap(err, "error getting cpu usage")
+	}
+
+	memorySamples, err := querySamples(memoryQuery, end.Add(-retention), end, step)
+	if err != nil {
+		return errors.Wrap(err, "error getting memory usage")
+	}
+
+	result := &types.Seasonality{}
+
+	if profile := seasonality.Analyze(cpuSamples, location); profile != nil && profile.HasPeak() {
+		result.PeakHours = profile.PeakHours()
+		result.PeakWeekdays = profile.PeakWeekdaysNames()
+		result.PeakRatio = fmt.Sprintf("%.1f", profile.PeakRatio)
+		result.ScheduledScaling = profile.PeakRatio >= *config.Get().SeasonalityRatio
+
+		if value, ok := profile.PeakMedian(cpuSamples); ok {
+			result.PeakCPURequest = types.FormatResource(types.CPUResourcePlaningType, value)
+		}
+
+		if value, ok := profile.PeakMedian(memorySamples); ok {
+			result.PeakMemoryRequest = types.FormatResource(types.MemoryResourcePlaningType, value)
+		}
+	}
+
+	seasonalityCache[cacheKey] = result
+	pod.Seasonality = result
+
+	return nil
+}
+
+// SetPeakRequests replaces recomended requests with median usage in peak window,
+// cached recomendations of workload are copied before change.
+func SetPeakRequests(pod *types.PodResources) {
+	if pod.GetRecomendation() == nil || pod.Seasonality == nil {
+		return
+	}
+
+	recomendation := pod.GetRecomendation().Copy()
+	pod.SetRecomendation(recomendation)
+
+	if value := pod.Seasonality.PeakCPURequest; len(value) > 0 && value != recomendation.CPURequest {
+		recomendation.CPURequest = value
+		recomendation.AddExplainAdjustment("CPURequest", "replaced with median usage in peak window "+pod.Seasonality.GetPeakWindow()) //nolint:lll
+	}
+
+	if value := pod.Seasonality.PeakMemoryRequest; len(value) > 0 && value != recomendation.MemoryRequest {
+		recomendation.MemoryRequest = value
+		recomendation.AddExplainAdjustment("MemoryRequest", "replaced with median usage in peak window "+pod.Seasonality.GetPeakWindow()) //nolint:lll
+	}
+}
+
+func querySamples(query string, start, end time.Time, step time.Duration) ([]seasonality.Sample, error) {
+	values, err := queryRangeValues(query, start, end, step)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]seasonality.Sample, 0, len(values))
+
+	for _, value := range values {
+		result = append(result, seasonality.Sample{Time: value.Timestamp.Time(), Value: float64(value.Value)})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,139 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+	"github.com/prometheus/common/model"
+)
+
+// getStartupIgnore returns period of container life that is ignored, 0 if startup is not ignored.
+func getStartupIgnore() (time.Duration, error) {
+	if len(*config.Get().StartupIgnore) == 0 {
+		return 0, nil
+	}
+
+	startup, err := model.ParseDuration(*config.Get().StartupIgnore)
+	if err != nil {
+		return 0, errors.Wrap(err, "error parsing startup.ignore")
+	}
+
+	return time.Duration(startup), nil
+}
+
+// containerAge returns promql of container age in seconds, container has series of every start
+// (different id and image labels after restarts), so last start is used.
+func containerAge(pod *types.PodResources, metricsExtra string) string {
+	return fmt.Sprintf(`(time() - max by (namespace,pod,container) (container_start_time_seconds{container="%s",namespace="%s"%s}))`, pod.ContainerName, pod.Namespace, metricsExtra) //nolint:lll
+}
+
+// usageFilter returns promql condition that removes startup of containers, usage of other images
+// and excluded windows from usage.
+func usageFilter(pod *types.PodResources, metricsExtra string) (string, error) {
+	filter := ""
+
+	startup, err := getStartupIgnore()
+	if err != nil {
+		return "", err
+	}
+
+	if startup > 0 {
+		filter += fmt.Sprintf(` and on(namespace,pod,container) %s > %.0f`, containerAge(pod, metricsExtra), startup.Seconds())
+	}
+
+	// usage only of running image
+	if *config.Get().ImageSplit && len(pod.Image) > 0 {
+		filter += fmt.Sprintf(` and on(namespace,pod,container) kube_pod_container_info{container="%s",namespace="%s",image="%s"%s}`, pod.ContainerName, pod.Namespace, pod.Image, metricsExtra) //nolint:lll
+	}
+
+	windows, err := config.GetExcludeWindows()
+	if err != nil {
+		return "", errors.Wrap(err, "error parsing exclude")
+	}
+
+	for _, window := range windows {
+		filter += fmt.Sprintf(` unless on() (vector(time()) >= %d and vector(time()) < %d)`, window.Start.Unix(), window.End.Unix())
+	}
+
+	return filter, nil
+}
+
+// usageRange returns range vector of usage during retention, with filter usage is calculated with subquery.
+func usageRange(usage, filter string, subquery bool) string {
+	if len(filter) > 0 {
+		return fmt.Sprintf(`(%s%s)[%s:1m]`, usage, filter, *config.Get().PrometheusRetention)
+	}
+
+	if subquery {
+		return fmt.Sprintf(`%s[%s:1m]`, usage, *config.Get().PrometheusRetention)
+	}
+
+	return fmt.Sprintf(`%s[%s]`, usage, *config.Get().PrometheusRetention)
+}
+
+// addStartupLimits adds limits that cover usage during startup of container.
+func addStartupLimits(pod *types.PodResources, metricsExtra, memoryUsage, cpuUsage string, result *types.Recomendations) error { //nolint:lll
+	startup, err := getStartupIgnore()
+	if err != nil {
+		return err
+	}
+
+	if startup == 0 {
+		return nil
+	}
+
+	startupFilter := fmt.Sprintf(` and on(namespace,pod,container) %s <= %.0f`, containerAge(pod, metricsExtra), startup.Seconds()) //nolint:lll
+
+	memoryQuery := fmt.Sprintf(`max(max_over_time(%s))`, usageRange(memoryUsage, startupFilter, true))
+	cpuQuery := fmt.Sprintf(`max(max_over_time(%s))`, usageRange(cpuUsage, startupFilter, true))
+
+	memory, err := metrics.Query(memoryQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting startup memory")
+	}
+
+	cpu, err := metrics.Query(cpuQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting startup cpu")
+	}
+
+	// startup-aware limit covers startup and usage after startup
+	if len(memory) == 1 {
+		value := math.Max(float64(memory[0].Value), utils.QuantityToFloat(result.MemoryLimit))
+
+		result.StartupMemoryLimit = utils.ByteCountSI(int64(value))
+	}
+
+	if len(cpu) == 1 {
+		value := math.Max(float64(cpu[0].Value), utils.QuantityToFloat(result.CPULimit))
+
+		result.StartupCPULimit = formatCPU(value)
+	}
+
+	if isExplain() {
+		result.Explain = append(result.Explain,
+			newExplanation("StartupMemoryLimit", "", "max", memoryQuery, memory, "max with MemoryLimit"),
+			newExplanation("StartupCPULimit", "", "max", cpuQuery, cpu, "max with CPULimit"),
+		)
+	}
+
+	return nil
+}
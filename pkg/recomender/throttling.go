@@ -0,0 +1,78 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package recomender
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/metrics"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+const percents = 100
+
+// addCPUThrottling adds ratio of throttled CFS periods, if container is throttled
+// cpu limit recomendation is raised or limit is recommended to be removed.
+func addCPUThrottling(pod *types.PodResources, metricsExtra string, result *types.Recomendations) error {
+	throttlingQuery := fmt.Sprintf(`max(sum by (pod) (increase(container_cpu_cfs_throttled_periods_total{container="%s",namespace="%s"%s}[%s])) / sum by (pod) (increase(container_cpu_cfs_periods_total{container="%s",namespace="%s"%s}[%s])))`, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention, pod.ContainerName, pod.Namespace, metricsExtra, *config.Get().PrometheusRetention) //nolint:lll
+
+	throttling, err := metrics.Query(throttlingQuery)
+	if err != nil {
+		return errors.Wrap(err, "error getting cpu throttling")
+	}
+
+	// container without cpu limit has no CFS periods
+	if len(throttling) != 1 || math.IsNaN(float64(throttling[0].Value)) {
+		return nil
+	}
+
+	ratio := float64(throttling[0].Value)
+
+	result.CPUThrottling = fmt.Sprintf("%.0f", ratio*percents)
+
+	if ratio*percents < *config.Get().ThrottlingThreshold {
+		return nil
+	}
+
+	result.CPUThrottled = true
+
+	if removeLimit := *config.Get().ThrottlingRemove; removeLimit > 0 && ratio*percents >= removeLimit {
+		result.RemoveCPULimit = true
+		result.AddExplainAdjustment("CPULimit", fmt.Sprintf("limit removed, throttled %s%% of periods is not less than %.0f%%", result.CPUThrottling, removeLimit)) //nolint:lll
+
+		return nil
+	}
+
+	// usage can not be bigger than limit, throttled container needs more than current limit
+	currentLimit := utils.QuantityToFloat(pod.CPULimit)
+	raisedLimit := currentLimit * (1 + ratio)
+	adjustment := fmt.Sprintf("raised to current limit %s * (1 + throttled %s%%)", pod.CPULimit, result.CPUThrottling)
+
+	if raisedLimit > utils.QuantityToFloat(result.CPULimit) {
+		result.CPULimit = types.FormatResource(types.CPUResourcePlaningType, raisedLimit)
+		result.AddExplainAdjustment("CPULimit", adjustment)
+	}
+
+	for i := range result.Strategies {
+		if raisedLimit > utils.QuantityToFloat(result.Strategies[i].CPULimit) {
+			result.Strategies[i].CPULimit = types.FormatResource(types.CPUResourcePlaningType, raisedLimit)
+			result.AddExplainAdjustment(types.LimitResourceName("CPULimit", i+1, result.Strategies[i].Strategy), adjustment)
+		}
+	}
+
+	return nil
+}
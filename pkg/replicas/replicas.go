@@ -0,0 +1,139 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package replicas
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+const percents = 100
+
+// Container of stateless workload with cpu usage of all replicas.
+type Workload struct {
+	Namespace     string
+	Name          string
+	Deployment    string
+	ContainerName string
+	Replicas      int
+	CPURequest    float64 // cores per pod
+	HPA           *types.HPA
+	// sum of cpu usage of all replicas during retention, cores
+	Usage []float64
+}
+
+// Proposal of replicas count and pod size that keeps headroom over peak usage.
+type Proposal struct {
+	Workload    *Workload
+	PeakUsage   float64
+	MedianUsage float64
+	// median usage to requested cpu of all replicas, percents
+	Utilization float64
+	// replicas with current pod size, 0 if cpu request is not set
+	Replicas int
+	// cpu request of pod with current replicas
+	CPURequest float64
+	// minReplicas of autoscaler for median usage with current pod size, 0 if cpu request is not set
+	MinReplicas int
+}
+
+// GetWorkloads groups containers of running pods of deployments by pod template.
+func GetWorkloads(pods []*types.PodResources) []*Workload {
+	workloads := make(map[string]*Workload)
+	result := make([]*Workload, 0)
+
+	for _, pod := range pods {
+		if pod.OwnerKind != "ReplicaSet" || len(pod.PodTemplate) == 0 || pod.InitContainer || pod.Phase != "Running" {
+			continue
+		}
+
+		key := pod.Namespace + "/" + pod.PodTemplate + "/" + pod.ContainerName
+
+		workload, ok := workloads[key]
+		if !ok {
+			workload = &Workload{
+				Namespace:     pod.Namespace,
+				Name:          pod.PodTemplate,
+				Deployment:    strings.TrimSuffix(pod.PodTemplate, "-"),
+				ContainerName: pod.ContainerName,
+				CPURequest:    utils.QuantityToFloat(pod.CPURequest),
+				HPA:           pod.HPA,
+			}
+
+			workloads[key] = workload
+			result = append(result, workload)
+		}
+
+		workload.Replicas++
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].ContainerName < result[j].ContainerName
+	})
+
+	return result
+}
+
+// Propose returns replicas count and pod size with headroom in percents over peak usage,
+// returns nil if workload has no usage.
+func Propose(workload *Workload, headroom float64, minReplicas int) *Proposal {
+	if len(workload.Usage) == 0 {
+		return nil
+	}
+
+	usage := append([]float64{}, workload.Usage...)
+
+	sort.Float64s(usage)
+
+	result := Proposal{
+		Workload:    workload,
+		PeakUsage:   usage[len(usage)-1],
+		MedianUsage: usage[len(usage)/2],
+	}
+
+	factor := 1 + headroom/percents
+
+	if workload.Replicas > 0 {
+		result.CPURequest = result.PeakUsage * factor / float64(workload.Replicas)
+	}
+
+	if workload.CPURequest > 0 {
+		result.Replicas = replicasFor(result.PeakUsage*factor, workload.CPURequest, minReplicas)
+		result.MinReplicas = replicasFor(result.MedianUsage*factor, workload.CPURequest, minReplicas)
+		result.Utilization = result.MedianUsage / (float64(workload.Replicas) * workload.CPURequest) * percents
+	}
+
+	return &result
+}
+
+func replicasFor(usage, podSize float64, minReplicas int) int {
+	replicas := int(math.Ceil(usage / podSize))
+
+	if replicas < minReplicas {
+		return minReplicas
+	}
+
+	return replicas
+}
@@ -0,0 +1,97 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package replicas_test
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/replicas"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestGetWorkloads(t *testing.T) {
+	t.Parallel()
+
+	pods := make([]*types.PodResources, 0)
+
+	for i := 0; i < 3; i++ {
+		pods = append(pods, &types.PodResources{
+			PodName:       fmt.Sprintf("app-%d", i),
+			PodTemplate:   "app-",
+			ContainerName: "app",
+			Namespace:     "test",
+			OwnerKind:     "ReplicaSet",
+			Phase:         "Running",
+			CPURequest:    "500m",
+		})
+	}
+
+	pods = append(pods, &types.PodResources{
+		PodName:       "fluentd-1",
+		PodTemplate:   "fluentd",
+		ContainerName: "fluentd",
+		Namespace:     "test",
+		OwnerKind:     "DaemonSet",
+		Phase:         "Running",
+	})
+
+	workloads := replicas.GetWorkloads(pods)
+
+	if len(workloads) != 1 {
+		t.Fatalf("want 1 workload, got %d", len(workloads))
+	}
+
+	if workloads[0].Replicas != 3 || workloads[0].CPURequest != 0.5 {
+		t.Fatalf("want 3 replicas with 0.5 cpu, got %d with %f", workloads[0].Replicas, workloads[0].CPURequest)
+	}
+
+	if workloads[0].Deployment != "app" {
+		t.Fatalf("want app deployment, got %s", workloads[0].Deployment)
+	}
+}
+
+func TestPropose(t *testing.T) {
+	t.Parallel()
+
+	// 10 replicas of 1 core with 2 cores peak usage
+	workload := &replicas.Workload{
+		Replicas:   10,
+		CPURequest: 1,
+		Usage:      []float64{0.5, 1, 1, 2, 1},
+	}
+
+	proposal := replicas.Propose(workload, 50, 2)
+
+	// 2 cores * 1.5 headroom = 3 cores
+	if proposal.Replicas != 3 {
+		t.Fatalf("want 3 replicas, got %d", proposal.Replicas)
+	}
+
+	if math.Abs(proposal.CPURequest-0.3) > 0.0001 {
+		t.Fatalf("want 0.3 cpu request, got %f", proposal.CPURequest)
+	}
+
+	if proposal.MinReplicas != 2 {
+		t.Fatalf("want 2 min replicas, got %d", proposal.MinReplicas)
+	}
+
+	if proposal.Utilization != 10 {
+		t.Fatalf("want 10%% utilization, got %f", proposal.Utilization)
+	}
+
+	if replicas.Propose(&replicas.Workload{}, 50, 2) != nil {
+		t.Fatal("want nil proposal without usage")
+	}
+}
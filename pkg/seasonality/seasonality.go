@@ -0,0 +1,228 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package seasonality
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	hoursInDay  = 24
+	daysInWeek  = 7
+	halfDivisor = 2
+	// hours and weekdays with average usage bigger than this part of maximum are peak.
+	peakThreshold = 0.8
+)
+
+// Usage of resource at time.
+type Sample struct {
+	Time  time.Time
+	Value float64
+}
+
+// Usage profile by hour of day and weekday.
+type Profile struct {
+	Hours        [hoursInDay]float64 // average usage per hour of day
+	Weekdays     [daysInWeek]float64 // average usage per weekday, sunday is first
+	PeakStart    int                 // first hour of peak window
+	PeakEnd      int                 // hour after peak window
+	PeakWeekdays []time.Weekday
+	PeakRatio    float64 // average usage in peak hours to average usage in off-peak hours
+	location     *time.Location
+}
+
+// Analyze returns usage profile of samples in location, returns nil if there are no samples.
+func Analyze(samples []Sample, location *time.Location) *Profile {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	profile := Profile{location: location}
+
+	var (
+		hourCount    [hoursInDay]int
+		weekdayCount [daysInWeek]int
+	)
+
+	for _, sample := range samples {
+		t := sample.Time.In(location)
+
+		profile.Hours[t.Hour()] += sample.Value
+		hourCount[t.Hour()]++
+
+		profile.Weekdays[t.Weekday()] += sample.Value
+		weekdayCount[t.Weekday()]++
+	}
+
+	for hour := range profile.Hours {
+		if hourCount[hour] > 0 {
+			profile.Hours[hour] /= float64(hourCount[hour])
+		}
+	}
+
+	for weekday := range profile.Weekdays {
+		if weekdayCount[weekday] > 0 {
+			profile.Weekdays[weekday] /= float64(weekdayCount[weekday])
+		}
+	}
+
+	profile.setPeakWindow()
+	profile.setPeakWeekdays(weekdayCount)
+
+	return &profile
+}
+
+// setPeakWindow finds contiguous hours around maximum hour with usage near to maximum.
+func (p *Profile) setPeakWindow() {
+	maxHour := 0
+
+	for hour, value := range p.Hours {
+		if value > p.Hours[maxHour] {
+			maxHour = hour
+		}
+	}
+
+	threshold := p.Hours[maxHour] * peakThreshold
+	length := 1
+
+	p.PeakStart = maxHour
+
+	for length < hoursInDay && p.Hours[(p.PeakStart+hoursInDay-1)%hoursInDay] >= threshold {
+		p.PeakStart = (p.PeakStart + hoursInDay - 1) % hoursInDay
+		length++
+	}
+
+	p.PeakEnd = (maxHour + 1) % hoursInDay
+
+	for length < hoursInDay && p.Hours[p.PeakEnd] >= threshold {
+		p.PeakEnd = (p.PeakEnd + 1) % hoursInDay
+		length++
+	}
+
+	// usage without daily pattern
+	if length == hoursInDay {
+		p.PeakRatio = 1
+
+		return
+	}
+
+	peak, offPeak := 0.0, 0.0
+
+	for hour, value := range p.Hours {
+		if p.isPeakHour(hour) {
+			peak += value
+		} else {
+			offPeak += value
+		}
+	}
+
+	peak /= float64(length)
+	offPeak /= float64(hoursInDay - length)
+
+	if offPeak == 0 {
+		p.PeakRatio = math.Inf(1)
+
+		return
+	}
+
+	p.PeakRatio = peak / offPeak
+}
+
+func (p *Profile) setPeakWeekdays(weekdayCount [daysInWeek]int) {
+	maxWeekday := 0.0
+
+	for _, value := range p.Weekdays {
+		maxWeekday = math.Max(maxWeekday, value)
+	}
+
+	for weekday, value := range p.Weekdays {
+		if weekdayCount[weekday] > 0 && value >= maxWeekday*peakThreshold {
+			p.PeakWeekdays = append(p.PeakWeekdays, time.Weekday(weekday))
+		}
+	}
+}
+
+func (p *Profile) isPeakHour(hour int) bool {
+	if p.PeakStart < p.PeakEnd {
+		return hour >= p.PeakStart && hour < p.PeakEnd
+	}
+
+	return hour >= p.PeakStart || hour < p.PeakEnd
+}
+
+// InPeak returns true if time is in peak hours of peak weekdays.
+func (p *Profile) InPeak(t time.Time) bool {
+	t = t.In(p.location)
+
+	if !p.isPeakHour(t.Hour()) {
+		return false
+	}
+
+	for _, weekday := range p.PeakWeekdays {
+		if t.Weekday() == weekday {
+			return true
+		}
+	}
+
+	return false
+}
+
+// HasPeak returns true if usage has daily pattern.
+func (p *Profile) HasPeak() bool {
+	return p.PeakRatio > 1
+}
+
+// PeakHours returns peak window, for example 09-18.
+func (p *Profile) PeakHours() string {
+	return fmt.Sprintf("%02d-%02d", p.PeakStart, p.PeakEnd)
+}
+
+// PeakWeekdaysNames returns names of peak weekdays, for example Mon,Tue,Wed,Thu,Fri.
+func (p *Profile) PeakWeekdaysNames() string {
+	names := make([]string, 0, len(p.PeakWeekdays))
+
+	for _, weekday := range p.PeakWeekdays {
+		names = append(names, weekday.String()[:3])
+	}
+
+	return strings.Join(names, ",")
+}
+
+// PeakMedian returns median of samples in peak hours of peak weekdays.
+func (p *Profile) PeakMedian(samples []Sample) (float64, bool) {
+	values := make([]float64, 0)
+
+	for _, sample := range samples {
+		if p.InPeak(sample.Time) {
+			values = append(values, sample.Value)
+		}
+	}
+
+	if len(values) == 0 {
+		return 0, false
+	}
+
+	sort.Float64s(values)
+
+	middle := len(values) / halfDivisor
+
+	if len(values)%halfDivisor == 0 {
+		return (values[middle-1] + values[middle]) / halfDivisor, true
+	}
+
+	return values[middle], true
+}
@@ -0,0 +1,83 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package seasonality_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/seasonality"
+)
+
+// business hours usage, 1 core from 9 to 18 on weekdays, 0.1 core otherwise.
+func businessHours() []seasonality.Sample {
+	samples := make([]seasonality.Sample, 0)
+
+	// 2024-01-01 is monday
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	for t := start; t.Before(start.Add(14 * 24 * time.Hour)); t = t.Add(30 * time.Minute) {
+		value := 0.1
+
+		if t.Hour() >= 9 && t.Hour() < 18 && t.Weekday() != time.Saturday && t.Weekday() != time.Sunday {
+			value = 1
+		}
+
+		samples = append(samples, seasonality.Sample{Time: t, Value: value})
+	}
+
+	return samples
+}
+
+func TestAnalyze(t *testing.T) {
+	t.Parallel()
+
+	samples := businessHours()
+
+	profile := seasonality.Analyze(samples, time.UTC)
+
+	if got := profile.PeakHours(); got != "09-18" {
+		t.Fatalf("want peak hours 09-18, got %s", got)
+	}
+
+	if got := profile.PeakWeekdaysNames(); got != "Mon,Tue,Wed,Thu,Fri" {
+		t.Fatalf("want weekdays peak, got %s", got)
+	}
+
+	// peak hours average is (5*1+2*0.1)/7, off-peak hours average is 0.1
+	if profile.PeakRatio < 7 || profile.PeakRatio > 8 {
+		t.Fatalf("want peak ratio about 7.4, got %f", profile.PeakRatio)
+	}
+
+	if median, ok := profile.PeakMedian(samples); !ok || median != 1 {
+		t.Fatalf("want peak median 1, got %f", median)
+	}
+
+	if seasonality.Analyze(nil, time.UTC) != nil {
+		t.Fatal("want nil profile without samples")
+	}
+}
+
+func TestAnalyzeWithoutPeak(t *testing.T) {
+	t.Parallel()
+
+	samples := make([]seasonality.Sample, 0)
+
+	for i := 0; i < 48; i++ {
+		samples = append(samples, seasonality.Sample{Time: time.Unix(int64(i*3600), 0), Value: 1})
+	}
+
+	if profile := seasonality.Analyze(samples, time.UTC); profile.HasPeak() {
+		t.Fatalf("want no peak, got %s with ratio %f", profile.PeakHours(), profile.PeakRatio)
+	}
+}
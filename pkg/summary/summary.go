@@ -0,0 +1,101 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package summary
+
+import (
+	"math"
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/config"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// name of cluster totals.
+const ClusterName = "cluster"
+
+// Totals of requested and recommended resources, cpu in cores, memory in bytes.
+type Totals struct {
+	Name                    string
+	Containers              int
+	CPURequest              float64
+	RecomendedCPURequest    float64
+	ReclaimableCPU          float64
+	MemoryRequest           float64
+	RecomendedMemoryRequest float64
+	ReclaimableMemory       float64
+	// savings per month, calculated only if cost model is configured
+	MonthlySavings float64
+}
+
+func (t *Totals) add(pod *types.PodResources) {
+	t.Containers++
+
+	cpuRequest := utils.QuantityToFloat(pod.CPURequest)
+	memoryRequest := utils.QuantityToFloat(pod.MemoryRequest)
+
+	// containers without recomendations will not be changed
+	recomendedCPURequest := cpuRequest
+	recomendedMemoryRequest := memoryRequest
+
+	if recomendation := pod.GetRecomendation(); recomendation != nil {
+		if len(recomendation.CPURequest) > 0 {
+			recomendedCPURequest = utils.QuantityToFloat(recomendation.CPURequest)
+		}
+
+		if len(recomendation.MemoryRequest) > 0 {
+			recomendedMemoryRequest = utils.QuantityToFloat(recomendation.MemoryRequest)
+		}
+	}
+
+	reclaimableCPU := math.Max(0, cpuRequest-recomendedCPURequest)
+	reclaimableMemory := math.Max(0, memoryRequest-recomendedMemoryRequest)
+
+	t.CPURequest += cpuRequest
+	t.RecomendedCPURequest += recomendedCPURequest
+	t.ReclaimableCPU += reclaimableCPU
+	t.MemoryRequest += memoryRequest
+	t.RecomendedMemoryRequest += recomendedMemoryRequest
+	t.ReclaimableMemory += reclaimableMemory
+
+	if cost := config.Get().Cost; cost != nil {
+		t.MonthlySavings += cost.GetMonthlyCost(pod.NodePool, reclaimableCPU, reclaimableMemory)
+	}
+}
+
+// Get returns totals per namespace sorted by name and totals for cluster.
+func Get(pods []*types.PodResources) ([]*Totals, *Totals) {
+	cluster := Totals{Name: ClusterName}
+	namespaces := make(map[string]*Totals)
+
+	for _, pod := range pods {
+		if _, ok := namespaces[pod.Namespace]; !ok {
+			namespaces[pod.Namespace] = &Totals{Name: pod.Namespace}
+		}
+
+		namespaces[pod.Namespace].add(pod)
+		cluster.add(pod)
+	}
+
+	result := make([]*Totals, 0, len(namespaces))
+
+	for _, totals := range namespaces {
+		result = append(result, totals)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Name < result[j].Name
+	})
+
+	return result, &cluster
+}
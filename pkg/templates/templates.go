@@ -0,0 +1,94 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package templates
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+)
+
+const percents = 100
+
+// Funcs returns helper functions of output templates.
+func Funcs() template.FuncMap {
+	return template.FuncMap{
+		// value of result field by name, for example {{ field . "MemoryRequestScore" }}
+		"field": func(pod *types.PodResources, name string) (string, error) {
+			return pod.GetFieldValue(name)
+		},
+		// recomendations of pod, empty if recomendations are not calculated
+		"recomendation": func(pod *types.PodResources) *types.Recomendations {
+			if recomendation := pod.GetRecomendation(); recomendation != nil {
+				return recomendation
+			}
+
+			return &types.Recomendations{}
+		},
+		// quantity as number, cores for cpu and bytes for memory
+		"quantity": utils.QuantityToFloat,
+		"cpu": func(value float64) string {
+			return types.FormatResource(types.CPUResourcePlaningType, value)
+		},
+		"memory": func(value float64) string {
+			return types.FormatResource(types.MemoryResourcePlaningType, value)
+		},
+		// how much current quantity is bigger than recomended in percents
+		"delta": types.ResourceDelta,
+		// part of value in total in percents
+		"percent": func(value, total float64) string {
+			if total == 0 {
+				return ""
+			}
+
+			return fmt.Sprintf("%.1f", value/total*percents)
+		},
+		"join":  strings.Join,
+		"upper": strings.ToUpper,
+		"lower": strings.ToLower,
+	}
+}
+
+// Parse returns template from -output value, template=path.tmpl reads template from file,
+// template-inline={{ ... }} uses inline template.
+func Parse(output string) (*template.Template, error) {
+	var text string
+
+	switch {
+	case strings.HasPrefix(output, types.OutputTemplateFilePrefix):
+		path := strings.TrimPrefix(output, types.OutputTemplateFilePrefix)
+
+		textByte, err := os.ReadFile(path)
+		if err != nil {
+			return nil, errors.Wrapf(err, "error opening template %s", path)
+		}
+
+		text = string(textByte)
+	case strings.HasPrefix(output, types.OutputTemplateInlinePrefix):
+		text = strings.TrimPrefix(output, types.OutputTemplateInlinePrefix)
+	default:
+		return nil, errors.Errorf("output %s is not template", output)
+	}
+
+	result, err := template.New("output").Funcs(Funcs()).Parse(text)
+	if err != nil {
+		return nil, errors.Wrap(err, "error parsing template")
+	}
+
+	return result, nil
+}
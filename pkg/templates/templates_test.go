@@ -0,0 +1,57 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package templates_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/templates"
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	pod := &types.PodResources{PodName: "app", CPURequest: "200m", MemoryRequest: "1Gi"}
+	pod.SetRecomendation(&types.Recomendations{CPURequest: "100m"})
+
+	empty := &types.PodResources{PodName: "empty"}
+
+	output := "template-inline={{ range . }}" +
+		"{{ .PodName }} {{ (recomendation .).CPURequest }} {{ delta .CPURequest (recomendation .).CPURequest }}% " +
+		"{{ field . \"CPURequestScore\" }} {{ cpu (quantity .CPURequest) }} {{ percent 1 4 }}\n{{ end }}"
+
+	outputTemplate, err := templates.Parse(output)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var b bytes.Buffer
+
+	if err := outputTemplate.Execute(&b, []*types.PodResources{pod, empty}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := "app 100m 100% Bad 200m 25.0\nempty  % Unknown 0m 25.0\n"
+
+	if b.String() != want {
+		t.Fatalf("want %q, got %q", want, b.String())
+	}
+
+	for _, invalid := range []string{"template-inline={{ .PodName", "template=not-found.tmpl", "text"} {
+		if _, err := templates.Parse(invalid); err == nil {
+			t.Fatalf("want error for %s", invalid)
+		}
+	}
+}
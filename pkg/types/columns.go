@@ -0,0 +1,49 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Columns of table that are not fields of results.
+const (
+	ColumnHPA   = "HPA"   // autoscaler with effect of recomendations
+	ColumnDebug = "Debug" // result in json
+)
+
+// ParseColumns parses comma separated list of table columns, for example PodName,NodeName,MemoryRequest,
+// columns are fields of results or HPA and Debug.
+func ParseColumns(columns string) ([]string, error) {
+	result := make([]string, 0)
+
+	if len(columns) == 0 {
+		return result, nil
+	}
+
+	for _, column := range strings.Split(columns, ",") {
+		column = strings.TrimPrefix(strings.TrimSpace(column), ".")
+
+		if column != ColumnHPA && column != ColumnDebug {
+			if _, err := GetField(column); err != nil {
+				return nil, errors.Errorf("unknown column %s", column)
+			}
+		}
+
+		result = append(result, column)
+	}
+
+	return result, nil
+}
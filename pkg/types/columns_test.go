@@ -0,0 +1,41 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestParseColumns(t *testing.T) {
+	t.Parallel()
+
+	columns, err := types.ParseColumns("PodName, .MemoryRequestDelta,HPA")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := strings.Join(columns, ","); got != "PodName,MemoryRequestDelta,HPA" {
+		t.Fatalf("unexpected columns %s", got)
+	}
+
+	if columns, err := types.ParseColumns(""); err != nil || len(columns) != 0 {
+		t.Fatalf("empty columns must be valid, got %v %v", columns, err)
+	}
+
+	if _, err := types.ParseColumns("PodName,Unknown"); err == nil {
+		t.Fatal("unknown column must return error")
+	}
+}
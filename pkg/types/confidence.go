@@ -0,0 +1,87 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Confidence of recomendation, depends on amount of metrics used to calculate recomendation.
+type Confidence string
+
+const (
+	ConfidenceLow    = Confidence("Low")
+	ConfidenceMedium = Confidence("Medium")
+	ConfidenceHigh   = Confidence("High")
+)
+
+const (
+	// minimal number of samples for medium confidence.
+	minConfidenceSamples = 60
+	// part of retention period that metrics must cover.
+	highConfidenceCoverage   = 0.8
+	mediumConfidenceCoverage = 0.25
+)
+
+func ParseConfidence(confidence string) (Confidence, error) {
+	switch strings.ToLower(confidence) {
+	case "low":
+		return ConfidenceLow, nil
+	case "medium":
+		return ConfidenceMedium, nil
+	case "high":
+		return ConfidenceHigh, nil
+	default:
+		return "", errors.Errorf("unknown confidence %s", confidence)
+	}
+}
+
+// GetConfidence returns confidence of recomendation calculated from number of samples
+// that covers span of time in retention period.
+func GetConfidence(samples int, span, retention time.Duration) Confidence {
+	if samples < minConfidenceSamples || retention <= 0 {
+		return ConfidenceLow
+	}
+
+	coverage := float64(span) / float64(retention)
+
+	switch {
+	case coverage >= highConfidenceCoverage:
+		return ConfidenceHigh
+	case coverage >= mediumConfidenceCoverage:
+		return ConfidenceMedium
+	default:
+		return ConfidenceLow
+	}
+}
+
+func (c Confidence) level() int {
+	switch c {
+	case ConfidenceHigh:
+		return 3 //nolint:gomnd
+	case ConfidenceMedium:
+		return 2 //nolint:gomnd
+	case ConfidenceLow:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// AtLeast returns true if confidence is equal or higher than min.
+func (c Confidence) AtLeast(min Confidence) bool {
+	return c.level() >= min.level()
+}
@@ -0,0 +1,47 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestGetConfidence(t *testing.T) {
+	t.Parallel()
+
+	const retention = 7 * 24 * time.Hour
+
+	tests := []struct {
+		samples int
+		span    time.Duration
+		want    types.Confidence
+	}{
+		{samples: 20000, span: 7 * 24 * time.Hour, want: types.ConfidenceHigh},
+		{samples: 10000, span: 3 * 24 * time.Hour, want: types.ConfidenceMedium},
+		{samples: 240, span: time.Hour, want: types.ConfidenceLow},
+		{samples: 10, span: 7 * 24 * time.Hour, want: types.ConfidenceLow},
+	}
+
+	for _, test := range tests {
+		if got := types.GetConfidence(test.samples, test.span, retention); got != test.want {
+			t.Fatalf("samples=%d span=%s: want %s, got %s", test.samples, test.span, test.want, got)
+		}
+	}
+
+	if !types.ConfidenceHigh.AtLeast(types.ConfidenceMedium) || types.ConfidenceLow.AtLeast(types.ConfidenceMedium) {
+		t.Fatal("wrong confidence order")
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+// Explanation how recomendation of resource was calculated.
+type Explanation struct {
+	Resource    string
+	Strategy    string
+	Function    string
+	Query       string
+	Value       string // raw value from prometheus
+	Adjustments []string
+}
+
+// AddExplainAdjustment adds adjustment to explanation of resource if explanation exists.
+func (r *Recomendations) AddExplainAdjustment(resource, adjustment string) {
+	for i := range r.Explain {
+		if r.Explain[i].Resource == resource {
+			r.Explain[i].Adjustments = append(r.Explain[i].Adjustments, adjustment)
+		}
+	}
+}
+
+// ExplainScore returns planing score of request with reason.
+func (r *PodResources) ExplainScore(planingType ResourcePlaningType) (ResourcePlaningResult, string) {
+	if planingType == CPUResourcePlaningType {
+		return scoreResourcePlaningReason(planingType, r.CPURequest, r.recomended().CPURequest)
+	}
+
+	return scoreResourcePlaningReason(planingType, r.MemoryRequest, r.recomended().MemoryRequest)
+}
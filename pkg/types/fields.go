@@ -0,0 +1,375 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+const percents = 100
+
+// Field of pod results that can be used in filter expressions.
+type Field struct {
+	Name string
+	// value of field is known only after recomendations are calculated
+	Recomendation bool
+	value         func(r *PodResources) string
+}
+
+// recomended returns recomendations or empty values if recomendations are not calculated.
+func (r *PodResources) recomended() *Recomendations {
+	if r.recomendations == nil {
+		return &Recomendations{}
+	}
+
+	return r.recomendations
+}
+
+//nolint:gochecknoglobals
+var fields = []Field{
+	{Name: "PodName", value: func(r *PodResources) string { return r.PodName }},
+	{Name: "PodTemplate", value: func(r *PodResources) string { return r.PodTemplate }},
+	{Name: "ContainerName", value: func(r *PodResources) string { return r.ContainerName }},
+	{Name: "Image", value: func(r *PodResources) string { return r.Image }},
+	{Name: "NodeName", value: func(r *PodResources) string { return r.NodeName }},
+	{Name: "NodePool", value: func(r *PodResources) string { return r.NodePool }},
+	{Name: "Namespace", value: func(r *PodResources) string { return r.Namespace }},
+	{Name: "MemoryRequest", value: func(r *PodResources) string { return r.MemoryRequest }},
+	{Name: "MemoryLimit", value: func(r *PodResources) string { return r.MemoryLimit }},
+	{Name: "CPURequest", value: func(r *PodResources) string { return r.CPURequest }},
+	{Name: "CPULimit", value: func(r *PodResources) string { return r.CPULimit }},
+	{
+		Name:  "MemoryLimitRequestRatio",
+		value: func(r *PodResources) string { return limitRequestRatio(r.MemoryRequest, r.MemoryLimit) },
+	},
+	{
+		Name:  "CPULimitRequestRatio",
+		value: func(r *PodResources) string { return limitRequestRatio(r.CPURequest, r.CPULimit) },
+	},
+	{Name: "QoS", value: func(r *PodResources) string { return r.QoS }},
+	{Name: "SafeToEvict", value: func(r *PodResources) string { return strconv.FormatBool(r.SafeToEvict) }},
+	{Name: "OOMKilled", value: func(r *PodResources) string { return strconv.FormatBool(r.OOMKilled) }},
+	{Name: "Evicted", value: func(r *PodResources) string { return strconv.FormatBool(r.Evicted) }},
+	{Name: "InitContainer", value: func(r *PodResources) string { return strconv.FormatBool(r.InitContainer) }},
+	{Name: "Phase", value: func(r *PodResources) string { return r.Phase }},
+	{Name: "OwnerKind", value: func(r *PodResources) string { return r.OwnerKind }},
+	{Name: "OwnerName", value: func(r *PodResources) string { return r.OwnerName }},
+	{
+		Name:          "RecomendedMemoryRequest",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().MemoryRequest },
+	},
+	{
+		Name:          "RecomendedMemoryLimit",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().MemoryLimit },
+	},
+	{
+		Name:          "RecomendedCPURequest",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().CPURequest },
+	},
+	{
+		Name:          "RecomendedCPULimit",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().CPULimit },
+	},
+	{
+		Name:          "RecomendedOOMKilled",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return strconv.FormatBool(r.recomended().OOMKilled) },
+	},
+	{
+		Name:          "CPUThrottling",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().CPUThrottling },
+	},
+	{
+		Name:          "RemoveCPULimit",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return strconv.FormatBool(r.recomended().RemoveCPULimit) },
+	},
+	{Name: "MemoryGrowth", Recomendation: true, value: func(r *PodResources) string { return r.MemoryGrowth }},
+	{Name: "HoursToMemoryLimit", Recomendation: true, value: func(r *PodResources) string { return r.HoursToMemoryLimit }},
+	{Name: "OOMRisk", Recomendation: true, value: func(r *PodResources) string { return string(r.OOMRisk) }},
+	{Name: "LimitRange", value: func(r *PodResources) string { return r.limitRange().Name }},
+	{Name: "LimitRangeDefaults", value: func(r *PodResources) string { return strings.Join(r.LimitRangeDefaults, ",") }},
+	{
+		Name:          "LimitRangeViolations",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return strings.Join(r.recomended().LimitRangeViolations, ",")
+		},
+	},
+	{Name: "HPAName", value: func(r *PodResources) string { return r.hpa().Name }},
+	{Name: "HPAMinReplicas", value: func(r *PodResources) string { return formatInt32(r.hpa().MinReplicas) }},
+	{Name: "HPAMaxReplicas", value: func(r *PodResources) string { return formatInt32(r.hpa().MaxReplicas) }},
+	{Name: "HPATargetCPU", value: func(r *PodResources) string { return formatInt32(r.hpa().TargetCPU) }},
+	{Name: "HPATargetMemory", value: func(r *PodResources) string { return formatInt32(r.hpa().TargetMemory) }},
+	{
+		Name:          "HPAReplicasChange",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			if effect := r.recomended().HPA; effect != nil && effect.ReplicasChange > 0 {
+				return fmt.Sprintf("%.1f", effect.ReplicasChange)
+			}
+
+			return ""
+		},
+	},
+	{Name: "Restarts", value: func(r *PodResources) string { return strconv.Itoa(r.Restarts) }},
+	{Name: "OOMCount", value: func(r *PodResources) string { return strconv.Itoa(r.GetOOMCount()) }},
+	{Name: "LastOOM", value: func(r *PodResources) string { return formatTime(r.GetLastOOM()) }},
+	{Name: "Samples", Recomendation: true, value: func(r *PodResources) string { return strconv.Itoa(r.recomended().Samples) }}, //nolint:lll
+	{
+		Name:          "DataSpanHours",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return fmt.Sprintf("%.1f", r.recomended().DataSpan.Hours())
+		},
+	},
+	{Name: "Confidence", Recomendation: true, value: func(r *PodResources) string { return string(r.recomended().Confidence) }}, //nolint:lll
+	{
+		Name:          "StartupMemoryLimit",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.recomended().StartupMemoryLimit },
+	},
+	{Name: "StartupCPULimit", Recomendation: true, value: func(r *PodResources) string { return r.recomended().StartupCPULimit }}, //nolint:lll
+	{Name: "PeakWindow", Recomendation: true, value: func(r *PodResources) string { return r.Seasonality.GetPeakWindow() }},       //nolint:lll
+	{Name: "PeakRatio", Recomendation: true, value: func(r *PodResources) string { return r.seasonality().PeakRatio }},
+	{Name: "PeakCPURequest", Recomendation: true, value: func(r *PodResources) string { return r.seasonality().PeakCPURequest }}, //nolint:lll
+	{
+		Name:          "PeakMemoryRequest",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.seasonality().PeakMemoryRequest },
+	},
+	{
+		Name:          "ScheduledScaling",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return strconv.FormatBool(r.seasonality().ScheduledScaling) },
+	},
+	{
+		Name:          "CPUUsage",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.formatUsage(CPUResourcePlaningType) },
+	},
+	{
+		Name:          "MemoryUsage",
+		Recomendation: true,
+		value:         func(r *PodResources) string { return r.formatUsage(MemoryResourcePlaningType) },
+	},
+	{
+		Name:          "MemoryRequestDelta",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return ResourceDelta(r.MemoryRequest, r.recomended().MemoryRequest)
+		},
+	},
+	{
+		Name:          "MemoryLimitDelta",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return ResourceDelta(r.MemoryLimit, r.recomended().MemoryLimit)
+		},
+	},
+	{
+		Name:          "CPURequestDelta",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return ResourceDelta(r.CPURequest, r.recomended().CPURequest)
+		},
+	},
+	{
+		Name:          "CPULimitDelta",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return ResourceDelta(r.CPULimit, r.recomended().CPULimit)
+		},
+	},
+	{
+		Name:          "MemoryRequestWaste",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return resourceWaste(MemoryResourcePlaningType, r.MemoryRequest, r.recomended().MemoryRequest)
+		},
+	},
+	{
+		Name:          "CPURequestWaste",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return resourceWaste(CPUResourcePlaningType, r.CPURequest, r.recomended().CPURequest)
+		},
+	},
+	{
+		Name:          "MemoryRequestScore",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return scoreResourcePlaning(MemoryResourcePlaningType, r.MemoryRequest, r.recomended().MemoryRequest).Name()
+		},
+	},
+	{
+		Name:          "CPURequestScore",
+		Recomendation: true,
+		value: func(r *PodResources) string {
+			return scoreResourcePlaning(CPUResourcePlaningType, r.CPURequest, r.recomended().CPURequest).Name()
+		},
+	},
+}
+
+// GetFields returns all fields of pod results.
+func GetFields() []Field {
+	return fields
+}
+
+// GetFieldNames returns names of all fields of pod results.
+func GetFieldNames() []string {
+	result := make([]string, 0, len(fields))
+
+	for _, field := range fields {
+		result = append(result, field.Name)
+	}
+
+	return result
+}
+
+// GetField returns field by name.
+func GetField(name string) (*Field, error) {
+	for i := range fields {
+		if fields[i].Name == name {
+			return &fields[i], nil
+		}
+	}
+
+	return nil, errors.Errorf("unknown field %s", name)
+}
+
+// GetFieldValue returns formatted value of field by name.
+func (r *PodResources) GetFieldValue(name string) (string, error) {
+	field, err := GetField(name)
+	if err != nil {
+		return "", err
+	}
+
+	return field.value(r), nil
+}
+
+// formatInt32 returns empty value for 0.
+func formatInt32(value int32) string {
+	if value == 0 {
+		return ""
+	}
+
+	return strconv.Itoa(int(value))
+}
+
+// formatTime formats time in RFC3339, zero time is empty string.
+func formatTime(value time.Time) string {
+	if value.IsZero() {
+		return ""
+	}
+
+	return value.UTC().Format(time.RFC3339)
+}
+
+// formatUsage returns formatted usage of resource, empty if usage is unknown.
+func (r *PodResources) formatUsage(planingType ResourcePlaningType) string {
+	if r.Usage == nil {
+		return ""
+	}
+
+	if planingType == CPUResourcePlaningType {
+		return FormatResource(planingType, r.Usage.CPU)
+	}
+
+	return FormatResource(planingType, r.Usage.Memory)
+}
+
+// ResourceDelta returns how much current value is bigger than recomended in percents,
+// positive values are over-provisioned resources, negative values are under-provisioned.
+func ResourceDelta(current, recomended string) string {
+	currentValue, recomendedValue, ok := parseResources(current, recomended)
+	if !ok {
+		return ""
+	}
+
+	if recomendedValue == 0 {
+		if currentValue == 0 {
+			return "0"
+		}
+
+		return ""
+	}
+
+	return strconv.FormatFloat(math.Round((currentValue-recomendedValue)/recomendedValue*percents), 'f', 0, 64)
+}
+
+// limitRequestRatio returns limit divided by request, empty if request or limit is not set.
+func limitRequestRatio(request, limit string) string {
+	requestValue, limitValue, ok := parseResources(request, limit)
+	if !ok || requestValue == 0 || limitValue == 0 {
+		return ""
+	}
+
+	return strconv.FormatFloat(limitValue/requestValue, 'f', 2, 64)
+}
+
+// resourceWaste returns absolute difference between current and recomended value,
+// negative values are resources that are missing.
+func resourceWaste(planingType ResourcePlaningType, current, recomended string) string {
+	currentValue, recomendedValue, ok := parseResources(current, recomended)
+	if !ok {
+		return ""
+	}
+
+	return FormatResource(planingType, currentValue-recomendedValue)
+}
+
+// FormatResource formats cpu cores in millicores and memory bytes in the same units as recomendations.
+func FormatResource(planingType ResourcePlaningType, value float64) string {
+	if planingType == CPUResourcePlaningType {
+		// avoid -0m in result
+		return fmt.Sprintf("%.0fm", math.Round(value*utils.BytesUnit)+0)
+	}
+
+	if value < 0 {
+		return "-" + utils.ByteCountIEC(int64(-value))
+	}
+
+	return utils.ByteCountIEC(int64(value))
+}
+
+func parseResources(current, recomended string) (float64, float64, bool) {
+	if len(current) == 0 || len(recomended) == 0 {
+		return 0, 0, false
+	}
+
+	currentQuantity, err := resource.ParseQuantity(current)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	recomendedQuantity, err := resource.ParseQuantity(recomended)
+	if err != nil {
+		return 0, 0, false
+	}
+
+	return currentQuantity.AsApproximateFloat64(), recomendedQuantity.AsApproximateFloat64(), true
+}
@@ -0,0 +1,157 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func newPod(name, memoryRequest, cpuRequest, recomendedMemory, recomendedCPU string) *types.PodResources {
+	pod := types.PodResources{
+		PodName:       name,
+		MemoryRequest: memoryRequest,
+		CPURequest:    cpuRequest,
+	}
+
+	pod.SetRecomendation(&types.Recomendations{
+		MemoryRequest: recomendedMemory,
+		CPURequest:    recomendedCPU,
+	})
+
+	return &pod
+}
+
+func TestFieldValues(t *testing.T) {
+	t.Parallel()
+
+	pod := newPod("test", "200Mi", "100m", "150Mi", "150m")
+
+	tests := map[string]string{
+		"PodName":                 "test",
+		"RecomendedMemoryRequest": "150Mi",
+		"MemoryRequestDelta":      "33",
+		"CPURequestDelta":         "-33",
+		"CPURequestWaste":         "-50m",
+		"MemoryRequestWaste":      "50Mi",
+		"MemoryRequestScore":      "Good",
+		"CPURequestScore":         "Bad",
+		"MemoryLimitDelta":        "",
+	}
+
+	for field, want := range tests {
+		got, err := pod.GetFieldValue(field)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("%s: want %q, got %q", field, want, got)
+		}
+	}
+
+	if _, err := pod.GetFieldValue("Unknown"); err == nil {
+		t.Fatal("want error for unknown field")
+	}
+}
+
+func TestSortPodResources(t *testing.T) {
+	t.Parallel()
+
+	pods := []*types.PodResources{
+		newPod("a", "100Mi", "100m", "100Mi", "100m"),
+		newPod("b", "2Gi", "100m", "100Mi", "100m"),
+		newPod("c", "0", "100m", "", "100m"),
+		newPod("d", "500Mi", "100m", "100Mi", "100m"),
+	}
+
+	sortBy, err := types.ParseSortBy("MemoryRequestWaste:desc,PodName")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	types.SortPodResources(pods, sortBy)
+
+	want := []string{"b", "d", "a", "c"}
+
+	for i, pod := range pods {
+		if pod.PodName != want[i] {
+			t.Fatalf("position %d: want %s, got %s", i, want[i], pod.PodName)
+		}
+	}
+
+	if _, err := types.ParseSortBy("PodName:up"); err == nil {
+		t.Fatal("want error for unknown sort order")
+	}
+}
+
+func TestExplainScore(t *testing.T) {
+	t.Parallel()
+
+	pod := newPod("test", "200Mi", "100m", "150Mi", "150m")
+
+	if score, _ := pod.ExplainScore(types.MemoryResourcePlaningType); score != types.GoodResourcePlaningResult {
+		t.Fatalf("want Good memory score, got %s", score.Name())
+	}
+
+	score, reason := pod.ExplainScore(types.CPUResourcePlaningType)
+	if score != types.BadResourcePlaningResult {
+		t.Fatalf("want Bad cpu score, got %s", score.Name())
+	}
+
+	if want := "difference 50m is not less than 20m"; reason != want {
+		t.Fatalf("want %q, got %q", want, reason)
+	}
+}
+
+func TestFormatResource(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"200Mi": "200Mi",
+		"1Gi":   "1Gi",
+		"-64Mi": "-64Mi",
+		"512":   "512",
+	}
+
+	for in, want := range tests {
+		quantity := resource.MustParse(in)
+
+		if got := types.FormatResource(types.MemoryResourcePlaningType, quantity.AsApproximateFloat64()); got != want {
+			t.Fatalf("%s: want %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestRecomendationsCopy(t *testing.T) {
+	t.Parallel()
+
+	recomendation := &types.Recomendations{
+		CPURequest: "100m",
+		Explain:    []types.Explanation{{Resource: "CPURequest"}},
+	}
+
+	result := recomendation.Copy()
+	result.CPURequest = "200m"
+	result.AddExplainAdjustment("CPURequest", "changed")
+
+	if recomendation.CPURequest != "100m" || len(recomendation.Explain[0].Adjustments) != 0 {
+		t.Fatalf("want original recomendation without changes, got %+v", recomendation)
+	}
+
+	if len(result.Explain[0].Adjustments) != 1 {
+		t.Fatalf("want adjustment in copy, got %+v", result.Explain)
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// Source of OOMKilled event.
+type OOMEventSource string
+
+const (
+	OOMEventSourceStatus     = OOMEventSource("status")
+	OOMEventSourceEvent      = OOMEventSource("event")
+	OOMEventSourcePrometheus = OOMEventSource("prometheus")
+)
+
+// OOMKilled event of container with memory limit at this time.
+type OOMEvent struct {
+	Time        time.Time
+	MemoryLimit string
+	Source      OOMEventSource
+	// number of OOMKilled restarts in event, 0 is one restart
+	Count int
+}
+
+// GetCount returns number of OOMKilled restarts of event.
+func (e OOMEvent) GetCount() int {
+	if e.Count < 1 {
+		return 1
+	}
+
+	return e.Count
+}
+
+// AddOOMEvent adds event to history if there is no other event in window,
+// existing event in window keeps the biggest number of restarts.
+func (r *PodResources) AddOOMEvent(event OOMEvent, window time.Duration) {
+	for i, existing := range r.OOMHistory {
+		diff := existing.Time.Sub(event.Time)
+
+		if diff <= window && diff >= -window {
+			if event.GetCount() > existing.GetCount() {
+				r.OOMHistory[i].Count = event.GetCount()
+			}
+
+			return
+		}
+	}
+
+	r.OOMHistory = append(r.OOMHistory, event)
+
+	sort.Slice(r.OOMHistory, func(i, j int) bool {
+		return r.OOMHistory[i].Time.Before(r.OOMHistory[j].Time)
+	})
+}
+
+// GetOOMCount returns number of OOMKilled restarts of container.
+func (r *PodResources) GetOOMCount() int {
+	count := 0
+
+	for _, event := range r.OOMHistory {
+		count += event.GetCount()
+	}
+
+	return count
+}
+
+// GetLastOOM returns time of last OOMKilled event, zero time if container was not killed.
+func (r *PodResources) GetLastOOM() time.Time {
+	if len(r.OOMHistory) == 0 {
+		return time.Time{}
+	}
+
+	return r.OOMHistory[len(r.OOMHistory)-1].Time
+}
@@ -0,0 +1,44 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestOOMCount(t *testing.T) {
+	t.Parallel()
+
+	pod := &types.PodResources{}
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	pod.AddOOMEvent(types.OOMEvent{Time: start, Source: types.OOMEventSourceStatus}, 0)
+	// crash loop during step of range query
+	pod.AddOOMEvent(types.OOMEvent{Time: start.Add(time.Minute), Source: types.OOMEventSourcePrometheus, Count: 4}, 10*time.Minute) //nolint:lll
+	pod.AddOOMEvent(types.OOMEvent{Time: start.Add(time.Hour), Source: types.OOMEventSourcePrometheus, Count: 2}, 10*time.Minute)   //nolint:lll
+
+	if len(pod.OOMHistory) != 2 {
+		t.Fatalf("want 2 events, got %d", len(pod.OOMHistory))
+	}
+
+	if got := pod.GetOOMCount(); got != 6 {
+		t.Fatalf("want 6 OOMKilled restarts, got %d", got)
+	}
+
+	if got, _ := pod.GetFieldValue("OOMCount"); got != "6" {
+		t.Fatalf("want OOMCount 6, got %s", got)
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// HorizontalPodAutoscaler of pod workload.
+type HPA struct {
+	Name         string
+	MinReplicas  int32
+	MaxReplicas  int32
+	TargetCPU    int32 // target average utilization of cpu in percents, 0 if cpu is not used
+	TargetMemory int32 // target average utilization of memory in percents, 0 if memory is not used
+	// target is utilization of pod (Resource metric), otherwise utilization of container (ContainerResource metric)
+	PodCPU    bool
+	PodMemory bool
+}
+
+// Effect of recomended requests on horizontal pod autoscaler.
+type HPAEffect struct {
+	ReplicasChange        float64 // expected change of replicas count with recomended requests
+	KeepRequests          bool    // requests are not changed to keep replicas count
+	SuggestedTargetCPU    int32   // target utilization to keep replicas count with recomended cpu request
+	SuggestedTargetMemory int32   // target utilization to keep replicas count with recomended memory request
+}
+
+// String returns HPA name, replicas range and targets, for example app 2-10 cpu:50%.
+func (h *HPA) String() string {
+	if h == nil {
+		return ""
+	}
+
+	result := []string{h.Name, fmt.Sprintf("%d-%d", h.MinReplicas, h.MaxReplicas)}
+
+	if h.TargetCPU > 0 {
+		result = append(result, fmt.Sprintf("cpu:%d%%", h.TargetCPU))
+	}
+
+	if h.TargetMemory > 0 {
+		result = append(result, fmt.Sprintf("memory:%d%%", h.TargetMemory))
+	}
+
+	return strings.Join(result, " ")
+}
+
+// String returns expected change of replicas or suggested targets if requests are kept.
+func (e *HPAEffect) String() string {
+	if e == nil || e.ReplicasChange == 0 {
+		return ""
+	}
+
+	if !e.KeepRequests {
+		return fmt.Sprintf("replicas x%.1f", e.ReplicasChange)
+	}
+
+	result := []string{"requests kept"}
+
+	if e.SuggestedTargetCPU > 0 {
+		result = append(result, fmt.Sprintf("or cpu:%d%%", e.SuggestedTargetCPU))
+	}
+
+	if e.SuggestedTargetMemory > 0 {
+		result = append(result, fmt.Sprintf("or memory:%d%%", e.SuggestedTargetMemory))
+	}
+
+	return strings.Join(result, " ")
+}
+
+func (r *PodResources) hpa() *HPA {
+	if r.HPA == nil {
+		return &HPA{}
+	}
+
+	return r.HPA
+}
@@ -0,0 +1,41 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestHPAString(t *testing.T) {
+	t.Parallel()
+
+	hpa := &types.HPA{Name: "app", MinReplicas: 2, MaxReplicas: 10, TargetCPU: 50}
+
+	if got := hpa.String(); got != "app 2-10 cpu:50%" {
+		t.Fatalf("want app 2-10 cpu:50%%, got %s", got)
+	}
+
+	tests := map[string]*types.HPAEffect{
+		"replicas x2.0":             {ReplicasChange: 2},
+		"requests kept or cpu:100%": {ReplicasChange: 2, KeepRequests: true, SuggestedTargetCPU: 100},
+		"":                          nil,
+	}
+
+	for want, effect := range tests {
+		if got := effect.String(); got != want {
+			t.Fatalf("want %q, got %q", want, got)
+		}
+	}
+}
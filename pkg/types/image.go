@@ -0,0 +1,79 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"sort"
+	"time"
+)
+
+// Usage of container with image during retention.
+type ImageVersion struct {
+	Image string
+	// last time when image was running, after rollback previous image is seen later than new image
+	LastSeen    time.Time
+	MemoryUsage float64 // median of memory usage of all pods in bytes
+	CPUUsage    float64 // median of cpu usage of all pods in cores
+}
+
+// Change of usage between consecutive image versions.
+type ImageChange struct {
+	From         string
+	To           string
+	MemoryChange float64 // percents
+	CPUChange    float64 // percents
+}
+
+// Verdict returns regression or improvement if change of any resource is bigger than threshold in percents.
+func (c ImageChange) Verdict(threshold float64) string {
+	if c.MemoryChange > threshold || c.CPUChange > threshold {
+		return "regression"
+	}
+
+	if c.MemoryChange < -threshold || c.CPUChange < -threshold {
+		return "improvement"
+	}
+
+	return ""
+}
+
+// GetImageChanges returns changes of usage between image versions in order of last seen,
+// so running image is compared with image that was running before it.
+func GetImageChanges(versions []ImageVersion) []ImageChange {
+	sorted := append([]ImageVersion{}, versions...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].LastSeen.Before(sorted[j].LastSeen)
+	})
+
+	result := make([]ImageChange, 0)
+
+	for i := 1; i < len(sorted); i++ {
+		result = append(result, ImageChange{
+			From:         sorted[i-1].Image,
+			To:           sorted[i].Image,
+			MemoryChange: usageChange(sorted[i-1].MemoryUsage, sorted[i].MemoryUsage),
+			CPUChange:    usageChange(sorted[i-1].CPUUsage, sorted[i].CPUUsage),
+		})
+	}
+
+	return result
+}
+
+func usageChange(from, to float64) float64 {
+	if from == 0 {
+		return 0
+	}
+
+	return (to - from) / from * percents
+}
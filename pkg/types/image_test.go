@@ -0,0 +1,69 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestGetImageChanges(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	versions := []types.ImageVersion{
+		{Image: "app:3", LastSeen: start.Add(2 * time.Hour), MemoryUsage: 300, CPUUsage: 0.5},
+		{Image: "app:1", LastSeen: start, MemoryUsage: 200, CPUUsage: 1},
+		{Image: "app:2", LastSeen: start.Add(time.Hour), MemoryUsage: 200, CPUUsage: 0.5},
+	}
+
+	changes := types.GetImageChanges(versions)
+
+	if len(changes) != 2 {
+		t.Fatalf("want 2 changes, got %d", len(changes))
+	}
+
+	want := []struct {
+		from, to, verdict string
+	}{
+		{from: "app:1", to: "app:2", verdict: "improvement"},
+		{from: "app:2", to: "app:3", verdict: "regression"},
+	}
+
+	for i, change := range changes {
+		if change.From != want[i].from || change.To != want[i].to || change.Verdict(20) != want[i].verdict {
+			t.Fatalf("change %d: want %v, got %+v %s", i, want[i], change, change.Verdict(20))
+		}
+	}
+}
+
+func TestGetImageChangesRollback(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	// app:2 was deployed after app:1 and rolled back to app:1
+	versions := []types.ImageVersion{
+		{Image: "app:1", LastSeen: start.Add(2 * time.Hour), MemoryUsage: 200, CPUUsage: 1},
+		{Image: "app:2", LastSeen: start.Add(time.Hour), MemoryUsage: 300, CPUUsage: 1},
+	}
+
+	changes := types.GetImageChanges(versions)
+
+	if len(changes) != 1 || changes[0].From != "app:2" || changes[0].To != "app:1" {
+		t.Fatalf("want change from app:2 to app:1, got %+v", changes)
+	}
+}
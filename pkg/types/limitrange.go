@@ -0,0 +1,159 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Container constraints of namespace LimitRanges, the strictest of all LimitRanges in namespace.
+type LimitRange struct {
+	Name      string // names of LimitRanges
+	MinMemory string
+	MaxMemory string
+	MinCPU    string
+	MaxCPU    string
+	// maxLimitRequestRatio, 0 if not set
+	MaxMemoryRatio float64
+	MaxCPURatio    float64
+}
+
+// annotation that LimitRanger admission plugin adds to pod when it sets default values.
+const LimitRangerAnnotation = "kubernetes.io/limit-ranger"
+
+// part of annotation, for example "cpu, memory request for container app".
+var limitRangerSetRe = regexp.MustCompile(`^(.+) (request|limit) for (?:init )?container (.+)$`)
+
+// ParseLimitRangerDefaults returns resources of container that were set by LimitRange defaults,
+// for example MemoryRequest, CPULimit.
+func ParseLimitRangerDefaults(annotation, containerName string) []string {
+	annotation = strings.TrimPrefix(annotation, "LimitRanger plugin set:")
+	result := make([]string, 0)
+
+	for _, part := range strings.Split(annotation, ";") {
+		match := limitRangerSetRe.FindStringSubmatch(strings.TrimSpace(part))
+		if match == nil || match[3] != containerName {
+			continue
+		}
+
+		for _, name := range strings.Split(match[1], ",") {
+			resourceName := ""
+
+			switch strings.TrimSpace(name) {
+			case "memory":
+				resourceName = "Memory"
+			case "cpu":
+				resourceName = "CPU"
+			default:
+				continue
+			}
+
+			if match[2] == "request" {
+				result = append(result, resourceName+"Request")
+			} else {
+				result = append(result, resourceName+"Limit")
+			}
+		}
+	}
+
+	return result
+}
+
+// IsLimitRangeDefault returns true if resource of container was set by LimitRange default.
+func (r *PodResources) IsLimitRangeDefault(resourceName string) bool {
+	for _, name := range r.LimitRangeDefaults {
+		if name == resourceName {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Check returns value clamped to LimitRange min and max, and violation if value is out of range.
+func (l *LimitRange) Check(planingType ResourcePlaningType, value string) (string, string) {
+	if l == nil || len(value) == 0 {
+		return value, ""
+	}
+
+	minValue, maxValue := l.MinMemory, l.MaxMemory
+	if planingType == CPUResourcePlaningType {
+		minValue, maxValue = l.MinCPU, l.MaxCPU
+	}
+
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return value, ""
+	}
+
+	if len(minValue) > 0 && quantity.Cmp(resource.MustParse(minValue)) < 0 {
+		return minValue, fmt.Sprintf("%s < min %s", value, minValue)
+	}
+
+	if len(maxValue) > 0 && quantity.Cmp(resource.MustParse(maxValue)) > 0 {
+		return maxValue, fmt.Sprintf("%s > max %s", value, maxValue)
+	}
+
+	return value, ""
+}
+
+// CheckRatio returns limit clamped to maxLimitRequestRatio of LimitRange,
+// and violation if ratio of limit to request is bigger.
+func (l *LimitRange) CheckRatio(planingType ResourcePlaningType, request, limit string) (string, string) {
+	if l == nil || len(request) == 0 || len(limit) == 0 {
+		return limit, ""
+	}
+
+	maxRatio := l.MaxMemoryRatio
+	if planingType == CPUResourcePlaningType {
+		maxRatio = l.MaxCPURatio
+	}
+
+	requestQuantity, err := resource.ParseQuantity(request)
+	if err != nil || maxRatio == 0 || requestQuantity.IsZero() {
+		return limit, ""
+	}
+
+	limitQuantity, err := resource.ParseQuantity(limit)
+	if err != nil {
+		return limit, ""
+	}
+
+	requestValue := requestQuantity.AsApproximateFloat64()
+
+	if limitQuantity.AsApproximateFloat64()/requestValue <= maxRatio {
+		return limit, ""
+	}
+
+	// rounded down to stay within ratio
+	clamped := resource.NewQuantity(int64(math.Floor(requestValue*maxRatio)), resource.BinarySI)
+	if planingType == CPUResourcePlaningType {
+		clamped = resource.NewMilliQuantity(int64(math.Floor(requestValue*maxRatio*1000)), resource.DecimalSI) //nolint:gomnd
+	}
+
+	return clamped.String(), fmt.Sprintf("%s/%s > maxLimitRequestRatio %s", limit, request, strconv.FormatFloat(maxRatio, 'f', -1, 64)) //nolint:lll
+}
+
+func (r *PodResources) limitRange() *LimitRange {
+	if r.LimitRange == nil {
+		return &LimitRange{}
+	}
+
+	return r.LimitRange
+}
@@ -0,0 +1,94 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestParseLimitRangerDefaults(t *testing.T) {
+	t.Parallel()
+
+	annotation := "LimitRanger plugin set: cpu, memory request for container app; cpu limit for container app; memory request for init container init" //nolint:lll
+
+	want := "CPURequest,MemoryRequest,CPULimit"
+
+	if got := strings.Join(types.ParseLimitRangerDefaults(annotation, "app"), ","); got != want {
+		t.Fatalf("want %s, got %s", want, got)
+	}
+
+	if got := strings.Join(types.ParseLimitRangerDefaults(annotation, "init"), ","); got != "MemoryRequest" {
+		t.Fatalf("want MemoryRequest, got %s", got)
+	}
+
+	if got := types.ParseLimitRangerDefaults("", "app"); len(got) != 0 {
+		t.Fatalf("want no defaults, got %v", got)
+	}
+}
+
+func TestLimitRangeCheck(t *testing.T) {
+	t.Parallel()
+
+	limitRange := &types.LimitRange{MinMemory: "64Mi", MaxMemory: "1Gi", MaxCPU: "2"}
+
+	tests := []struct {
+		planingType types.ResourcePlaningType
+		value       string
+		clamped     string
+		violation   string
+	}{
+		{types.MemoryResourcePlaningType, "50Mi", "64Mi", "50Mi < min 64Mi"},
+		{types.MemoryResourcePlaningType, "2Gi", "1Gi", "2Gi > max 1Gi"},
+		{types.MemoryResourcePlaningType, "100Mi", "100Mi", ""},
+		{types.CPUResourcePlaningType, "2500m", "2", "2500m > max 2"},
+		{types.CPUResourcePlaningType, "10m", "10m", ""},
+		{types.CPUResourcePlaningType, "", "", ""},
+	}
+
+	for _, test := range tests {
+		clamped, violation := limitRange.Check(test.planingType, test.value)
+		if clamped != test.clamped || violation != test.violation {
+			t.Fatalf("%s: want %s %q, got %s %q", test.value, test.clamped, test.violation, clamped, violation)
+		}
+	}
+}
+
+func TestLimitRangeCheckRatio(t *testing.T) {
+	t.Parallel()
+
+	limitRange := &types.LimitRange{MaxMemoryRatio: 2, MaxCPURatio: 1.5}
+
+	tests := []struct {
+		planingType types.ResourcePlaningType
+		request     string
+		limit       string
+		clamped     string
+		violation   string
+	}{
+		{types.MemoryResourcePlaningType, "1Gi", "4Gi", "2Gi", "4Gi/1Gi > maxLimitRequestRatio 2"},
+		{types.MemoryResourcePlaningType, "1Gi", "2Gi", "2Gi", ""},
+		{types.CPUResourcePlaningType, "100m", "1", "150m", "1/100m > maxLimitRequestRatio 1.5"},
+		{types.CPUResourcePlaningType, "0", "1", "1", ""},
+		{types.CPUResourcePlaningType, "", "1", "1", ""},
+	}
+
+	for _, test := range tests {
+		clamped, violation := limitRange.CheckRatio(test.planingType, test.request, test.limit)
+		if clamped != test.clamped || violation != test.violation {
+			t.Fatalf("%s/%s: want %s %q, got %s %q", test.limit, test.request, test.clamped, test.violation, clamped, violation)
+		}
+	}
+}
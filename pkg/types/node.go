@@ -0,0 +1,27 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import corev1 "k8s.io/api/core/v1"
+
+// Node results.
+type NodeResources struct {
+	NodeName          string
+	NodePool          string
+	AllocatableCPU    string
+	AllocatableMemory string
+	AllocatablePods   int64
+	Unschedulable     bool
+	Labels            map[string]string
+	Taints            []corev1.Taint
+}
@@ -0,0 +1,125 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"sort"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
+)
+
+// Resources of ResourceQuota that are changed by recomendations.
+const (
+	QuotaRequestsCPU    = "requests.cpu"
+	QuotaRequestsMemory = "requests.memory"
+	QuotaLimitsCPU      = "limits.cpu"
+	QuotaLimitsMemory   = "limits.memory"
+)
+
+// ResourceQuota of namespace, values are in cores and bytes.
+type ResourceQuota struct {
+	Namespace string
+	Name      string
+	Hard      map[string]float64
+	Used      map[string]float64
+}
+
+// Headroom of ResourceQuota resource before and after applying recomendations.
+type QuotaHeadroom struct {
+	Namespace     string
+	Name          string
+	Resource      string
+	Hard          float64
+	Used          float64
+	Headroom      float64
+	HeadroomAfter float64
+}
+
+// PlaningType returns type of quota resource.
+func (h *QuotaHeadroom) PlaningType() ResourcePlaningType {
+	if h.Resource == QuotaRequestsCPU || h.Resource == QuotaLimitsCPU {
+		return CPUResourcePlaningType
+	}
+
+	return MemoryResourcePlaningType
+}
+
+// Exceeded returns true if recomendations will be rejected by quota.
+func (h *QuotaHeadroom) Exceeded() bool {
+	return h.HeadroomAfter < 0
+}
+
+// GetQuotaHeadroom returns headroom of quotas with changes of recomendations of pods,
+// init containers and finished pods are not counted in quota usage.
+func GetQuotaHeadroom(quotas []ResourceQuota, pods []*PodResources) []QuotaHeadroom {
+	changes := make(map[string]map[string]float64)
+
+	for _, pod := range pods {
+		recomendation := pod.GetRecomendation()
+		if recomendation == nil || pod.InitContainer || (pod.Phase != "Running" && pod.Phase != "Pending") {
+			continue
+		}
+
+		if _, ok := changes[pod.Namespace]; !ok {
+			changes[pod.Namespace] = make(map[string]float64)
+		}
+
+		change := changes[pod.Namespace]
+
+		change[QuotaRequestsCPU] += resourceChange(pod.CPURequest, recomendation.CPURequest)
+		change[QuotaRequestsMemory] += resourceChange(pod.MemoryRequest, recomendation.MemoryRequest)
+		change[QuotaLimitsCPU] += resourceChange(pod.CPULimit, recomendation.CPULimit)
+		change[QuotaLimitsMemory] += resourceChange(pod.MemoryLimit, recomendation.MemoryLimit)
+	}
+
+	result := make([]QuotaHeadroom, 0)
+
+	for _, quota := range quotas {
+		for resourceName, hard := range quota.Hard {
+			used := quota.Used[resourceName]
+
+			result = append(result, QuotaHeadroom{
+				Namespace:     quota.Namespace,
+				Name:          quota.Name,
+				Resource:      resourceName,
+				Hard:          hard,
+				Used:          used,
+				Headroom:      hard - used,
+				HeadroomAfter: hard - used - changes[quota.Namespace][resourceName],
+			})
+		}
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+
+		if result[i].Name != result[j].Name {
+			return result[i].Name < result[j].Name
+		}
+
+		return result[i].Resource < result[j].Resource
+	})
+
+	return result
+}
+
+// resourceChange returns difference between recomended and current value, 0 if there is no recomendation.
+func resourceChange(current, recomended string) float64 {
+	if len(recomended) == 0 {
+		return 0
+	}
+
+	return utils.QuantityToFloat(recomended) - utils.QuantityToFloat(current)
+}
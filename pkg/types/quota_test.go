@@ -0,0 +1,52 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestGetQuotaHeadroom(t *testing.T) {
+	t.Parallel()
+
+	pod := &types.PodResources{Namespace: "default", Phase: "Running", CPURequest: "500m", MemoryRequest: "100Mi"}
+	pod.SetRecomendation(&types.Recomendations{CPURequest: "1500m"})
+
+	finished := &types.PodResources{Namespace: "default", Phase: "Succeeded", CPURequest: "500m"}
+	finished.SetRecomendation(&types.Recomendations{CPURequest: "1500m"})
+
+	quotas := []types.ResourceQuota{{
+		Namespace: "default",
+		Name:      "quota",
+		Hard:      map[string]float64{types.QuotaRequestsCPU: 4, types.QuotaRequestsMemory: 1e9},
+		Used:      map[string]float64{types.QuotaRequestsCPU: 3.5, types.QuotaRequestsMemory: 5e8},
+	}}
+
+	headrooms := types.GetQuotaHeadroom(quotas, []*types.PodResources{pod, finished})
+	if len(headrooms) != 2 {
+		t.Fatalf("want 2 headrooms, got %d", len(headrooms))
+	}
+
+	cpu := headrooms[0]
+
+	if cpu.Resource != types.QuotaRequestsCPU || cpu.Headroom != 0.5 || cpu.HeadroomAfter != -0.5 || !cpu.Exceeded() {
+		t.Fatalf("want cpu headroom 0.5 and -0.5 after, got %+v", cpu)
+	}
+
+	// memory request is not recomended
+	if memory := headrooms[1]; memory.HeadroomAfter != memory.Headroom || memory.Exceeded() {
+		t.Fatalf("want unchanged memory headroom, got %+v", memory)
+	}
+}
@@ -0,0 +1,40 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+// Daily and weekly pattern of workload cpu usage.
+type Seasonality struct {
+	PeakHours         string // peak window, for example 09-18
+	PeakWeekdays      string
+	PeakRatio         string // average cpu usage in peak hours to off-peak hours
+	PeakCPURequest    string // median cpu usage in peak window
+	PeakMemoryRequest string // median memory usage in peak window
+	ScheduledScaling  bool   // candidate for scheduled scaling
+}
+
+// GetPeakWindow returns peak hours and weekdays, empty if usage has no daily pattern.
+func (s *Seasonality) GetPeakWindow() string {
+	if s == nil || len(s.PeakHours) == 0 {
+		return ""
+	}
+
+	return s.PeakHours + " " + s.PeakWeekdays
+}
+
+func (r *PodResources) seasonality() *Seasonality {
+	if r.Seasonality == nil {
+		return &Seasonality{}
+	}
+
+	return r.Seasonality
+}
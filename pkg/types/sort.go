@@ -0,0 +1,100 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+// Sort results by field.
+type SortKey struct {
+	Field      string
+	Descending bool
+}
+
+// ParseSortBy parses comma separated list of fields, for example MemoryRequestWaste:desc,PodName:asc.
+func ParseSortBy(sortBy string) ([]SortKey, error) {
+	result := make([]SortKey, 0)
+
+	if len(sortBy) == 0 {
+		return result, nil
+	}
+
+	for _, item := range strings.Split(sortBy, ",") {
+		name, order, _ := strings.Cut(strings.TrimSpace(item), ":")
+
+		name = strings.TrimPrefix(name, ".")
+
+		if _, err := GetField(name); err != nil {
+			return nil, err
+		}
+
+		key := SortKey{Field: name}
+
+		switch strings.ToLower(order) {
+		case "", "asc":
+		case "desc":
+			key.Descending = true
+		default:
+			return nil, errors.Errorf("unknown sort order %s, must be asc or desc", order)
+		}
+
+		result = append(result, key)
+	}
+
+	return result, nil
+}
+
+// SortPodResources sorts results by keys, quantities are compared by value,
+// results without value are always last.
+func SortPodResources(pods []*PodResources, keys []SortKey) {
+	sort.SliceStable(pods, func(i, j int) bool {
+		for _, key := range keys {
+			left, _ := pods[i].GetFieldValue(key.Field)
+			right, _ := pods[j].GetFieldValue(key.Field)
+
+			if cmp := compareValues(left, right); cmp != 0 {
+				if len(left) == 0 || len(right) == 0 {
+					return len(right) == 0
+				}
+
+				if key.Descending {
+					return cmp > 0
+				}
+
+				return cmp < 0
+			}
+		}
+
+		return false
+	})
+}
+
+func compareValues(left, right string) int {
+	if left == right {
+		return 0
+	}
+
+	leftQuantity, leftErr := resource.ParseQuantity(left)
+	rightQuantity, rightErr := resource.ParseQuantity(right)
+
+	if leftErr == nil && rightErr == nil {
+		return leftQuantity.Cmp(rightQuantity)
+	}
+
+	return strings.Compare(left, right)
+}
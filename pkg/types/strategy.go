@@ -0,0 +1,113 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// Strategy calculates limits as quantile of resource usage, quantile 1 is max resource usage.
+type Strategy struct {
+	Name          string  `yaml:"-"`
+	LimitQuantile float64 `yaml:"limitQuantile"`
+}
+
+// Limits recomended by additional strategy.
+type StrategyLimits struct {
+	Strategy    string
+	MemoryLimit string
+	CPULimit    string
+}
+
+const aggressiveLimitQuantile = 0.99
+
+// Function returns prometheus function over time for range vector.
+func (s Strategy) Function(rangeVector string) string {
+	if s.LimitQuantile >= 1 {
+		return fmt.Sprintf("max_over_time(%s)", rangeVector)
+	}
+
+	return fmt.Sprintf("quantile_over_time(%g,%s)", s.LimitQuantile, rangeVector)
+}
+
+// FunctionName returns human readable name of strategy function.
+func (s Strategy) FunctionName() string {
+	if s.LimitQuantile >= 1 {
+		return "max"
+	}
+
+	return fmt.Sprintf("quantile %g", s.LimitQuantile)
+}
+
+// ParseStrategies parses comma separated list of built-in or custom strategies,
+// first strategy is used for recomended limits.
+func ParseStrategies(strategies string, custom map[string]Strategy) ([]Strategy, error) {
+	result := make([]Strategy, 0)
+	names := make(map[string]bool)
+
+	for _, name := range strings.Split(strategies, ",") {
+		name = strings.TrimSpace(name)
+
+		if names[name] {
+			return nil, errors.Errorf("duplicate strategy %s", name)
+		}
+
+		names[name] = true
+
+		strategy, err := getStrategy(name, custom)
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, strategy)
+	}
+
+	return result, nil
+}
+
+func getStrategy(name string, custom map[string]Strategy) (Strategy, error) {
+	if strategyType, err := ParseStrategyType(name); err == nil {
+		switch strategyType {
+		case StrategyTypeAggressive:
+			return Strategy{Name: name, LimitQuantile: aggressiveLimitQuantile}, nil
+		case StrategyTypeConservative:
+			return Strategy{Name: name, LimitQuantile: 1}, nil
+		}
+	}
+
+	strategy, ok := custom[name]
+	if !ok {
+		return Strategy{}, errors.Errorf("unknown strategy type %s", name)
+	}
+
+	if strategy.LimitQuantile <= 0 || strategy.LimitQuantile > 1 {
+		return Strategy{}, errors.Errorf("strategy %s limitQuantile must be between 0 and 1", name)
+	}
+
+	strategy.Name = name
+
+	return strategy, nil
+}
+
+// LimitResourceName returns name of limit resource of strategy,
+// first strategy uses resource name.
+func LimitResourceName(resource string, index int, strategy string) string {
+	if index == 0 {
+		return resource
+	}
+
+	return fmt.Sprintf("%s(%s)", resource, strategy)
+}
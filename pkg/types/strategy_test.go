@@ -0,0 +1,58 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestParseStrategies(t *testing.T) {
+	t.Parallel()
+
+	custom := map[string]types.Strategy{
+		"p95": {LimitQuantile: 0.95},
+	}
+
+	strategies, err := types.ParseStrategies("conservative, aggressive,p95", custom)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"max_over_time(m[7d])",
+		"quantile_over_time(0.99,m[7d])",
+		"quantile_over_time(0.95,m[7d])",
+	}
+
+	for i, strategy := range strategies {
+		if got := strategy.Function("m[7d]"); got != want[i] {
+			t.Fatalf("%s: want %s, got %s", strategy.Name, want[i], got)
+		}
+	}
+
+	if strategies[2].Name != "p95" {
+		t.Fatalf("want custom strategy name p95, got %s", strategies[2].Name)
+	}
+
+	for _, strategy := range []string{"unknown", "p95,p95"} {
+		if _, err := types.ParseStrategies(strategy, custom); err == nil {
+			t.Fatalf("want error for %s", strategy)
+		}
+	}
+
+	if _, err := types.ParseStrategies("p0", map[string]types.Strategy{"p0": {}}); err == nil {
+		t.Fatal("want error for zero quantile")
+	}
+}
@@ -16,36 +16,92 @@ import (
 	"encoding/json"
 	"fmt"
 	"math"
+	"strings"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 // Recommend for container resources.
 type Recomendations struct {
-	MemoryRequest string
-	MemoryLimit   string
-	CPURequest    string
-	CPULimit      string
-	OOMKilled     bool
-}
-
-// Pod results.
-type PodResources struct {
-	PodName        string
-	PodTemplate    string
-	ContainerName  string
-	NodeName       string
-	Namespace      string
 	MemoryRequest  string
 	MemoryLimit    string
 	CPURequest     string
 	CPULimit       string
-	QoS            string
-	SafeToEvict    bool
 	OOMKilled      bool
-	Evicted        bool
-	recomendations *Recomendations
+	CPUThrottling  string // percents of throttled CFS periods
+	CPUThrottled   bool
+	RemoveCPULimit bool
+	Samples        int           // number of memory usage samples in retention period
+	DataSpan       time.Duration // time between first and last sample
+	Confidence     Confidence
+	Explain        []Explanation
+	Strategies     []StrategyLimits // limits of additional strategies
+	// limits that cover usage during startup of container
+	StartupMemoryLimit string
+	StartupCPULimit    string
+	HPA                *HPAEffect
+	// recomendations out of LimitRange min and max, clamped with -limitRange.clamp
+	LimitRangeViolations []string
+}
+
+// Usage of container resources, cpu in cores, memory in bytes.
+type Usage struct {
+	CPU    float64
+	Memory float64
+}
+
+// Risk of container to reach memory limit.
+type OOMRisk string
+
+const (
+	OOMRiskLow    = OOMRisk("Low")
+	OOMRiskMedium = OOMRisk("Medium")
+	OOMRiskHigh   = OOMRisk("High")
+)
+
+// Pod results.
+type PodResources struct {
+	PodName         string
+	PodTemplate     string
+	ContainerName   string
+	NodeName        string
+	NodePool        string
+	Namespace       string
+	MemoryRequest   string
+	MemoryLimit     string
+	CPURequest      string
+	CPULimit        string
+	QoS             string
+	SafeToEvict     bool
+	OOMKilled       bool
+	Evicted         bool
+	InitContainer   bool
+	Usage           *Usage // average usage during prometheus retention
+	Labels          map[string]string
+	NamespaceLabels map[string]string
+	Phase           string
+	OwnerKind       string
+	OwnerName       string
+	NodeSelector    map[string]string
+	Tolerations     []corev1.Toleration
+	// memory usage growth per hour
+	MemoryGrowth       string
+	HoursToMemoryLimit string
+	OOMRisk            OOMRisk
+	Restarts           int
+	OOMHistory         []OOMEvent
+	Seasonality        *Seasonality
+	HPA                *HPA
+	PodCPURequest      float64 // requests of all containers of pod, init containers are not counted
+	PodMemoryRequest   float64
+	Image              string
+	ImageVersions      []ImageVersion
+	LimitRange         *LimitRange
+	LimitRangeDefaults []string // resources that were set by LimitRange defaults
+	recomendations     *Recomendations
 }
 
 func (r *PodResources) String() string {
@@ -61,6 +117,54 @@ func (r *PodResources) SetRecomendation(recomendations *Recomendations) {
 	r.recomendations = recomendations
 }
 
+// GetRecomendation returns recomendations, nil if recomendations are not calculated.
+func (r *PodResources) GetRecomendation() *Recomendations {
+	return r.recomendations
+}
+
+// Copy returns copy of recomendations that can be changed for one pod,
+// recomendations are cached and shared by all pods of workload.
+func (r *Recomendations) Copy() *Recomendations {
+	result := *r
+
+	result.Explain = make([]Explanation, len(r.Explain))
+
+	for i, explanation := range r.Explain {
+		explanation.Adjustments = append([]string{}, explanation.Adjustments...)
+		result.Explain[i] = explanation
+	}
+
+	result.Strategies = append([]StrategyLimits{}, r.Strategies...)
+	result.LimitRangeViolations = append([]string{}, r.LimitRangeViolations...)
+
+	if r.HPA != nil {
+		hpa := *r.HPA
+		result.HPA = &hpa
+	}
+
+	return &result
+}
+
+// IsDaemonSet returns true if pod is created by DaemonSet.
+func (r *PodResources) IsDaemonSet() bool {
+	return r.OwnerKind == "DaemonSet"
+}
+
+// GetWorkloadName returns namespace, kind and name of workload of pod, for example default/Deployment/app,
+// pods of replica sets with pod template are pods of deployments.
+func (r *PodResources) GetWorkloadName() string {
+	kind, name := r.OwnerKind, r.OwnerName
+
+	switch {
+	case kind == "ReplicaSet" && len(r.PodTemplate) > 0:
+		kind, name = "Deployment", strings.TrimSuffix(r.PodTemplate, "-")
+	case len(kind) == 0:
+		kind, name = "Pod", r.PodName
+	}
+
+	return fmt.Sprintf("%s/%s/%s", r.Namespace, kind, name)
+}
+
 func (r *PodResources) GetPodNamespaceName() string {
 	return fmt.Sprintf("%s/%s", r.Namespace, r.PodName)
 }
@@ -96,6 +200,14 @@ func (r *PodResources) GetFormattedResources() *PodResources { //nolint:cyclop
 		result.CPULimit = r.CPULimit
 	}
 
+	if r.recomendations.RemoveCPULimit {
+		result.CPULimit = fmt.Sprintf("%s / no limit", r.CPULimit)
+	}
+
+	if r.recomendations.CPUThrottled {
+		result.CPULimit = fmt.Sprintf("%s throttled %s%%", result.CPULimit, r.recomendations.CPUThrottling)
+	}
+
 	if r.recomendations.OOMKilled || r.OOMKilled {
 		result.OOMKilled = true
 	}
@@ -143,9 +255,33 @@ const (
 	GodResourcePlaningResult     ResourcePlaningResult = 4
 )
 
+// Name of planing result, used in filters and reports.
+func (p ResourcePlaningResult) Name() string {
+	switch p {
+	case BadResourcePlaningResult:
+		return "Bad"
+	case GoodResourcePlaningResult:
+		return "Good"
+	case PerfectResourcePlaningResult:
+		return "Perfect"
+	case GeniousResourcePlaningResult:
+		return "Genious"
+	case GodResourcePlaningResult:
+		return "God"
+	default:
+		return "Unknown"
+	}
+}
+
 func scoreResourcePlaning(planingType ResourcePlaningType, req, reqrecomend string) ResourcePlaningResult {
+	result, _ := scoreResourcePlaningReason(planingType, req, reqrecomend)
+
+	return result
+}
+
+func scoreResourcePlaningReason(planingType ResourcePlaningType, req, reqrecomend string) (ResourcePlaningResult, string) { //nolint:lll
 	if len(req) == 0 || len(reqrecomend) == 0 {
-		return UnknownResourcePlaningResult
+		return UnknownResourcePlaningResult, "request or recomendation is not set"
 	}
 
 	resReq := resource.MustParse(req)
@@ -153,11 +289,11 @@ func scoreResourcePlaning(planingType ResourcePlaningType, req, reqrecomend stri
 
 	f := resReqRecomend.AsApproximateFloat64() / resReq.AsApproximateFloat64()
 	if f == 1 {
-		return GodResourcePlaningResult
+		return GodResourcePlaningResult, "recomendation is equal to request"
 	}
 
 	if f >= 0.8 && f <= 1.2 {
-		return GeniousResourcePlaningResult
+		return GeniousResourcePlaningResult, fmt.Sprintf("recomendation is %.2f of request, between 0.8 and 1.2", f)
 	}
 
 	okDiffPerfect := resource.MustParse("10Mi")
@@ -169,16 +305,17 @@ func scoreResourcePlaning(planingType ResourcePlaningType, req, reqrecomend stri
 	}
 
 	planDiff := math.Abs(resReqRecomend.AsApproximateFloat64() - resReq.AsApproximateFloat64())
+	formattedDiff := FormatResource(planingType, planDiff)
 
 	if planDiff < okDiffPerfect.AsApproximateFloat64() {
-		return PerfectResourcePlaningResult
+		return PerfectResourcePlaningResult, fmt.Sprintf("difference %s is less than %s", formattedDiff, okDiffPerfect.String()) //nolint:lll
 	}
 
 	if planDiff < okDiffGood.AsApproximateFloat64() {
-		return GoodResourcePlaningResult
+		return GoodResourcePlaningResult, fmt.Sprintf("difference %s is less than %s", formattedDiff, okDiffGood.String())
 	}
 
-	return BadResourcePlaningResult
+	return BadResourcePlaningResult, fmt.Sprintf("difference %s is not less than %s", formattedDiff, okDiffGood.String())
 }
 
 // strategy to calculate resources.
@@ -221,3 +358,75 @@ func ParseGroupBy(groupBy string) (GroupBy, error) {
 		return "", errors.Errorf("unknown collector type %s", groupBy)
 	}
 }
+
+// Type of report.
+type ReportType string
+
+const (
+	ReportTypePods       = ReportType("pods")
+	ReportTypeChargeback = ReportType("chargeback")
+	ReportTypeNodes      = ReportType("nodes")
+	ReportTypeBinpack    = ReportType("binpack")
+	ReportTypeReplicas   = ReportType("replicas")
+	ReportTypePolicies   = ReportType("policies")
+)
+
+func ParseReportType(reportType string) (ReportType, error) {
+	switch reportType {
+	case "pods":
+		return ReportTypePods, nil
+	case "chargeback":
+		return ReportTypeChargeback, nil
+	case "nodes":
+		return ReportTypeNodes, nil
+	case "binpack":
+		return ReportTypeBinpack, nil
+	case "replicas":
+		return ReportTypeReplicas, nil
+	case "policies":
+		return ReportTypePolicies, nil
+	default:
+		return "", errors.Errorf("unknown report type %s", reportType)
+	}
+}
+
+// Format of result.
+type OutputFormat string
+
+const (
+	OutputFormatText     = OutputFormat("text")
+	OutputFormatSARIF    = OutputFormat("sarif")
+	OutputFormatJUnit    = OutputFormat("junit")
+	OutputFormatMarkdown = OutputFormat("markdown")
+	OutputFormatHTML     = OutputFormat("html")
+	// user template from file (template=path.tmpl) or inline (template-inline={{ ... }})
+	OutputFormatTemplate = OutputFormat("template")
+)
+
+const (
+	OutputTemplateFilePrefix   = "template="
+	OutputTemplateInlinePrefix = "template-inline="
+)
+
+func ParseOutputFormat(outputFormat string) (OutputFormat, error) {
+	for _, prefix := range []string{OutputTemplateFilePrefix, OutputTemplateInlinePrefix} {
+		if strings.HasPrefix(outputFormat, prefix) {
+			return OutputFormatTemplate, nil
+		}
+	}
+
+	switch outputFormat {
+	case "text":
+		return OutputFormatText, nil
+	case "sarif":
+		return OutputFormatSARIF, nil
+	case "junit":
+		return OutputFormatJUnit, nil
+	case "markdown":
+		return OutputFormatMarkdown, nil
+	case "html":
+		return OutputFormatHTML, nil
+	default:
+		return "", errors.Errorf("unknown output format %s", outputFormat)
+	}
+}
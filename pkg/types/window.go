@@ -0,0 +1,72 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types
+
+import (
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Period of time that is excluded from analysis, for example maintenance or incident.
+type TimeWindow struct {
+	Start  time.Time `yaml:"start"`
+	End    time.Time `yaml:"end"`
+	Reason string    `yaml:"reason"`
+}
+
+// ParseTimeWindows parses comma separated list of windows in RFC3339 format,
+// for example 2024-01-01T00:00:00Z/2024-01-01T06:00:00Z.
+func ParseTimeWindows(windows string) ([]TimeWindow, error) {
+	result := make([]TimeWindow, 0)
+
+	if len(windows) == 0 {
+		return result, nil
+	}
+
+	for _, item := range strings.Split(windows, ",") {
+		start, end, ok := strings.Cut(strings.TrimSpace(item), "/")
+		if !ok {
+			return nil, errors.Errorf("window %s must be start/end", item)
+		}
+
+		window := TimeWindow{}
+
+		var err error
+
+		if window.Start, err = time.Parse(time.RFC3339, start); err != nil {
+			return nil, errors.Wrapf(err, "error parsing start of window %s", item)
+		}
+
+		if window.End, err = time.Parse(time.RFC3339, end); err != nil {
+			return nil, errors.Wrapf(err, "error parsing end of window %s", item)
+		}
+
+		if err := window.Check(); err != nil {
+			return nil, err
+		}
+
+		result = append(result, window)
+	}
+
+	return result, nil
+}
+
+func (w TimeWindow) Check() error {
+	if !w.End.After(w.Start) {
+		return errors.Errorf("end of window %s must be after start", w.Start.Format(time.RFC3339))
+	}
+
+	return nil
+}
@@ -0,0 +1,42 @@
+/*
+Copyright paskal.maksim@gmail.com
+Licensed under the Apache License, Version 2.0 (the "License")
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+http://www.apache.org/licenses/LICENSE-2.0
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+package types_test
+
+import (
+	"testing"
+
+	"github.com/maksim-paskal/k8s-resources-cli/pkg/types"
+)
+
+func TestParseTimeWindows(t *testing.T) {
+	t.Parallel()
+
+	windows, err := types.ParseTimeWindows("2024-01-01T00:00:00Z/2024-01-01T06:00:00Z, 2024-02-01T10:00:00+02:00/2024-02-01T11:00:00+02:00") //nolint:lll
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(windows) != 2 {
+		t.Fatalf("want 2 windows, got %d", len(windows))
+	}
+
+	if got := windows[1].Start.Unix(); got != 1706774400 {
+		t.Fatalf("want start 1706774400, got %d", got)
+	}
+
+	for _, window := range []string{"2024-01-01T00:00:00Z", "2024-01-02T00:00:00Z/2024-01-01T00:00:00Z", "yesterday/today"} {
+		if _, err := types.ParseTimeWindows(window); err == nil {
+			t.Fatalf("want error for %s", window)
+		}
+	}
+}
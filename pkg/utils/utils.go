@@ -14,11 +14,15 @@ package utils
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
+
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 const (
-	BytesUnit = 1000
+	BytesUnit       = 1000
+	BinaryBytesUnit = 1024
 )
 
 func ByteCountSI(b int64) string {
@@ -38,3 +42,68 @@ func ByteCountSI(b int64) string {
 
 	return strings.ReplaceAll(result, ".00", "")
 }
+
+// ByteCountIEC formats bytes with binary suffixes and 1024 as unit, values less than 1Ki are bytes.
+func ByteCountIEC(b int64) string {
+	if b < BinaryBytesUnit {
+		return strconv.FormatInt(b, 10)
+	}
+
+	div, exp := int64(BinaryBytesUnit), 0
+	for n := b / BinaryBytesUnit; n >= BinaryBytesUnit; n /= BinaryBytesUnit {
+		div *= BinaryBytesUnit
+		exp++
+	}
+
+	q := float64(b) / float64(div)
+
+	result := fmt.Sprintf("%.2f%ci", q, "KMGTPE"[exp])
+
+	return strings.ReplaceAll(result, ".00", "")
+}
+
+// QuantityToFloat returns value of quantity, invalid or empty quantities are 0.
+func QuantityToFloat(value string) float64 {
+	quantity, err := resource.ParseQuantity(value)
+	if err != nil {
+		return 0
+	}
+
+	return quantity.AsApproximateFloat64()
+}
+
+// LinearRegression returns slope and intercept of line that fits points with least squares method,
+// values are centered by means so large xs (for example unix timestamps) keep precision.
+func LinearRegression(xs, ys []float64) (float64, float64, bool) {
+	if len(xs) != len(ys) || len(xs) < 2 { //nolint:gomnd
+		return 0, 0, false
+	}
+
+	var meanX, meanY float64
+
+	for i := range xs {
+		meanX += xs[i]
+		meanY += ys[i]
+	}
+
+	n := float64(len(xs))
+	meanX /= n
+	meanY /= n
+
+	var sumXY, sumXX float64
+
+	for i := range xs {
+		dx := xs[i] - meanX
+		sumXY += dx * (ys[i] - meanY)
+		sumXX += dx * dx
+	}
+
+	if sumXX == 0 {
+		return 0, 0, false
+	}
+
+	slope := sumXY / sumXX
+	intercept := meanY - slope*meanX
+
+	return slope, intercept, true
+}
@@ -13,6 +13,7 @@ limitations under the License.
 package utils_test
 
 import (
+	"math"
 	"testing"
 
 	"github.com/maksim-paskal/k8s-resources-cli/pkg/utils"
@@ -48,3 +49,77 @@ func TestMbToString(t *testing.T) {
 		}
 	}
 }
+
+func TestByteCountIEC(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"500":    "500",
+		"1Ki":    "1Ki",
+		"200Mi":  "200Mi",
+		"1.5Gi":  "1.50Gi",
+		"1100Mi": "1.07Gi",
+	}
+
+	for in, want := range tests {
+		quantity := resource.MustParse(in)
+
+		got := utils.ByteCountIEC(quantity.Value())
+
+		if _, err := resource.ParseQuantity(got); err != nil {
+			t.Fatal(err)
+		}
+
+		if got != want {
+			t.Fatalf("%s: want %s, got %s", in, want, got)
+		}
+	}
+}
+
+func TestLinearRegression(t *testing.T) {
+	t.Parallel()
+
+	slope, intercept, ok := utils.LinearRegression([]float64{0, 1, 2, 3}, []float64{1, 3, 5, 7})
+	if !ok {
+		t.Fatal("want result")
+	}
+
+	if slope != 2 || intercept != 1 {
+		t.Fatalf("want slope 2 and intercept 1, got %f and %f", slope, intercept)
+	}
+
+	// 1Mi per hour during week with step of 20 minutes
+	const (
+		start      = 1.7e9
+		step       = 1200
+		growth     = 1048576.0 / 3600
+		memoryBase = 209715200
+	)
+
+	xs := make([]float64, 0)
+	ys := make([]float64, 0)
+
+	for i := 0; i < 504; i++ {
+		x := start + float64(i*step)
+
+		xs = append(xs, x)
+		ys = append(ys, memoryBase+growth*(x-start))
+	}
+
+	slope, intercept, ok = utils.LinearRegression(xs, ys)
+	if !ok {
+		t.Fatal("want result")
+	}
+
+	if math.Abs(slope-growth)/growth > 1e-9 {
+		t.Fatalf("want slope %f, got %f", growth, slope)
+	}
+
+	if got := intercept + slope*start; math.Abs(got-memoryBase) > 1 {
+		t.Fatalf("want value %d at start, got %f", memoryBase, got)
+	}
+
+	if _, _, ok := utils.LinearRegression([]float64{1, 1}, []float64{1, 2}); ok {
+		t.Fatal("want no result for vertical line")
+	}
+}